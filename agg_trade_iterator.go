@@ -0,0 +1,148 @@
+package binance
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// MaxAggTradesLimit is maximum number of aggregate trades returned by
+	// single request.
+	MaxAggTradesLimit = 1000
+
+	// aggTradesWindow is maximum distance between StartTime and EndTime of
+	// AggTradesRequest.
+	aggTradesWindow = time.Hour
+)
+
+// AggTradeRangeRequest represents AggTradeIterator request data. Trades
+// starting with FromID, or made at StartTime or later when FromID is zero,
+// are returned until EndTime, exclusive. Zero EndTime means up to the
+// latest trade. When both FromID and StartTime are zero, iteration starts
+// from the most recent trades. Limit is page size, zero means
+// MaxAggTradesLimit.
+type AggTradeRangeRequest struct {
+	Symbol    string
+	FromID    int64
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int
+}
+
+// AggTradeIterator walks aggregate trades forward. Without FromID the first
+// trade is looked up in consecutive one hour windows from StartTime, as
+// exchange doesn't accept longer ones, then trades are paged by ID. Requests
+// go through the client, so they are subject to its rate limiting.
+type AggTradeIterator struct {
+	client  Client
+	request AggTradeRangeRequest
+
+	window time.Time
+	fromID int64
+	page   []*AggTrade
+	trade  *AggTrade
+	done   bool
+	err    error
+}
+
+// NewAggTradeIterator returns iterator over aggregate trades of atrr.
+func NewAggTradeIterator(client Client, atrr AggTradeRangeRequest) *AggTradeIterator {
+	if atrr.Limit <= 0 || atrr.Limit > MaxAggTradesLimit {
+		atrr.Limit = MaxAggTradesLimit
+	}
+	return &AggTradeIterator{
+		client:  client,
+		request: atrr,
+		window:  atrr.StartTime,
+		fromID:  atrr.FromID,
+	}
+}
+
+// Next advances to the next trade, fetching next page when needed. It
+// returns false once range is exhausted or request fails.
+func (it *AggTradeIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.fromID > 0 || it.window.IsZero() {
+			it.fetchByID(ctx)
+		} else {
+			it.fetchWindow(ctx)
+		}
+	}
+	it.trade = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// AggTrade returns current trade.
+func (it *AggTradeIterator) AggTrade() *AggTrade {
+	return it.trade
+}
+
+// Err returns error which stopped iteration.
+func (it *AggTradeIterator) Err() error {
+	return it.err
+}
+
+// fetchWindow looks for the first trade in window starting at it.window,
+// moving window forward when it's empty.
+func (it *AggTradeIterator) fetchWindow(ctx context.Context) {
+	end := it.window.Add(aggTradesWindow)
+	if !it.request.EndTime.IsZero() && end.After(it.request.EndTime) {
+		end = it.request.EndTime
+	}
+	trades, err := it.client.AggTrades(ctx, AggTradesRequest{
+		Symbol:    it.request.Symbol,
+		StartTime: it.window,
+		EndTime:   end.Add(-time.Millisecond),
+		Limit:     it.request.Limit,
+	})
+	if err != nil {
+		it.err = err
+		return
+	}
+	if len(trades) == 0 {
+		it.window = end
+		if (!it.request.EndTime.IsZero() && !end.Before(it.request.EndTime)) || end.After(time.Now()) {
+			it.done = true
+		}
+		return
+	}
+	it.add(trades)
+}
+
+func (it *AggTradeIterator) fetchByID(ctx context.Context) {
+	trades, err := it.client.AggTrades(ctx, AggTradesRequest{
+		Symbol: it.request.Symbol,
+		FromID: it.fromID,
+		Limit:  it.request.Limit,
+	})
+	if err != nil {
+		it.err = err
+		return
+	}
+	if len(trades) < it.request.Limit {
+		it.done = true
+	}
+	it.add(trades)
+}
+
+// add queues trades before EndTime and moves cursor after the last one.
+func (it *AggTradeIterator) add(trades []*AggTrade) {
+	for _, trade := range trades {
+		if int64(trade.ID) < it.fromID {
+			continue
+		}
+		if !it.request.EndTime.IsZero() && !trade.Timestamp.Before(it.request.EndTime) {
+			it.done = true
+			break
+		}
+		it.page = append(it.page, trade)
+		it.fromID = int64(trade.ID) + 1
+	}
+	if len(it.page) == 0 {
+		it.done = true
+	}
+}
@@ -0,0 +1,165 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BarBuilder builds klines from aggregate trades. Time bars cover buckets
+// of Period, tick, volume and quote volume bars are closed by the trade
+// which reaches the threshold, so they may slightly exceed it.
+//
+// Trades must be added in order, trades with ID not greater than the last
+// added one are ignored, so stream replayed after reconnect is harmless.
+type BarBuilder struct {
+	period      Period
+	trades      int
+	volume      Decimal
+	quoteVolume Decimal
+
+	current *Kline
+	end     time.Time
+	lastID  int
+	started bool
+}
+
+// ErrInvalidBarThreshold means bar builder was given nil period or
+// non-positive size.
+var ErrInvalidBarThreshold = errors.New("invalid bar threshold")
+
+// NewTimeBarBuilder returns builder of bars covering buckets of period, like
+// FixedPeriod(15 * time.Second). Buckets without trades produce no bars.
+func NewTimeBarBuilder(period Period) (*BarBuilder, error) {
+	if period == nil {
+		return nil, fmt.Errorf("%w: nil period", ErrInvalidBarThreshold)
+	}
+	return &BarBuilder{period: period}, nil
+}
+
+// NewTickBarBuilder returns builder of bars of given number of trades.
+// Trades are counted individually, not by aggregate trades.
+func NewTickBarBuilder(trades int) (*BarBuilder, error) {
+	if trades <= 0 {
+		return nil, fmt.Errorf("%w: %d trades", ErrInvalidBarThreshold, trades)
+	}
+	return &BarBuilder{trades: trades}, nil
+}
+
+// NewVolumeBarBuilder returns builder of bars of given base asset volume.
+func NewVolumeBarBuilder(volume Decimal) (*BarBuilder, error) {
+	if volume.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: volume %s", ErrInvalidBarThreshold, volume)
+	}
+	return &BarBuilder{volume: volume}, nil
+}
+
+// NewQuoteVolumeBarBuilder returns builder of bars of given quote asset
+// volume, also known as dollar bars.
+func NewQuoteVolumeBarBuilder(quoteVolume Decimal) (*BarBuilder, error) {
+	if quoteVolume.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: quote volume %s", ErrInvalidBarThreshold, quoteVolume)
+	}
+	return &BarBuilder{quoteVolume: quoteVolume}, nil
+}
+
+// Add merges trade into current bar and returns bars completed by it.
+func (b *BarBuilder) Add(trade *AggTrade) []*Kline {
+	if b.started && trade.ID <= b.lastID {
+		return nil
+	}
+	b.started, b.lastID = true, trade.ID
+
+	var completed []*Kline
+	if b.period != nil {
+		completed = b.AdvanceTo(trade.Timestamp)
+	}
+	if b.current == nil {
+		b.open(trade)
+	}
+	b.merge(trade)
+	if b.full() {
+		completed = append(completed, b.current)
+		b.current = nil
+	}
+	return completed
+}
+
+// AdvanceTo returns time bar which ended by t, letting bars be closed on
+// time when trades are sparse. It does nothing for other bar types.
+func (b *BarBuilder) AdvanceTo(t time.Time) []*Kline {
+	if b.period == nil || b.current == nil || t.Before(b.end) {
+		return nil
+	}
+	completed := b.current
+	b.current = nil
+	return []*Kline{completed}
+}
+
+// Partial returns copy of bar which didn't complete yet, nil if there's
+// none.
+func (b *BarBuilder) Partial() *Kline {
+	if b.current == nil {
+		return nil
+	}
+	partial := *b.current
+	return &partial
+}
+
+// Flush returns bar which didn't complete yet and starts a new one with
+// the next trade.
+func (b *BarBuilder) Flush() *Kline {
+	partial := b.current
+	b.current = nil
+	return partial
+}
+
+func (b *BarBuilder) open(trade *AggTrade) {
+	b.current = &Kline{
+		OpenTime: trade.Timestamp,
+		Open:     trade.Price,
+		High:     trade.Price,
+		Low:      trade.Price,
+	}
+	if b.period != nil {
+		b.current.OpenTime = b.period.Truncate(trade.Timestamp)
+		b.end = b.period.Next(b.current.OpenTime)
+		b.current.CloseTime = b.end.Add(-time.Millisecond)
+	}
+}
+
+func (b *BarBuilder) merge(trade *AggTrade) {
+	bar := b.current
+	if trade.Price.GreaterThan(bar.High) {
+		bar.High = trade.Price
+	}
+	if trade.Price.LessThan(bar.Low) {
+		bar.Low = trade.Price
+	}
+	bar.Close = trade.Price
+	if b.period == nil {
+		bar.CloseTime = trade.Timestamp
+	}
+	quoteQuantity := trade.Price.Mul(trade.Quantity)
+	bar.Volume = bar.Volume.Add(trade.Quantity)
+	bar.QuoteAssetVolume = bar.QuoteAssetVolume.Add(quoteQuantity)
+	bar.NumberOfTrades += trade.LastTradeID - trade.FirstTradeID + 1
+	// Buyer is taker unless buyer's order was resting in the book.
+	if !trade.BuyerMaker {
+		bar.TakerBuyBaseAssetVolume = bar.TakerBuyBaseAssetVolume.Add(trade.Quantity)
+		bar.TakerBuyQuoteAssetVolume = bar.TakerBuyQuoteAssetVolume.Add(quoteQuantity)
+	}
+}
+
+func (b *BarBuilder) full() bool {
+	bar := b.current
+	switch {
+	case b.trades > 0:
+		return bar.NumberOfTrades >= b.trades
+	case b.volume.Sign() > 0:
+		return !bar.Volume.LessThan(b.volume)
+	case b.quoteVolume.Sign() > 0:
+		return !bar.QuoteAssetVolume.LessThan(b.quoteVolume)
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package binance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var barEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// aggTrade returns aggregate trade of single trade at ms milliseconds after
+// barEpoch.
+func aggTrade(id int, ms int, price, quantity string, buyerMaker bool) *AggTrade {
+	return &AggTrade{
+		ID:           id,
+		Price:        MustParseDecimal(price),
+		Quantity:     MustParseDecimal(quantity),
+		FirstTradeID: id,
+		LastTradeID:  id,
+		Timestamp:    barEpoch.Add(time.Duration(ms) * time.Millisecond),
+		BuyerMaker:   buyerMaker,
+	}
+}
+
+func addTrades(b *BarBuilder, trades ...*AggTrade) []*Kline {
+	var completed []*Kline
+	for _, trade := range trades {
+		completed = append(completed, b.Add(trade)...)
+	}
+	return completed
+}
+
+func TestBarBuilderInvalidThreshold(t *testing.T) {
+	constructors := map[string]func() (*BarBuilder, error){
+		"nil period":          func() (*BarBuilder, error) { return NewTimeBarBuilder(nil) },
+		"zero trades":         func() (*BarBuilder, error) { return NewTickBarBuilder(0) },
+		"negative trades":     func() (*BarBuilder, error) { return NewTickBarBuilder(-1) },
+		"zero volume":         func() (*BarBuilder, error) { return NewVolumeBarBuilder(Decimal{}) },
+		"negative volume":     func() (*BarBuilder, error) { return NewVolumeBarBuilder(MustParseDecimal("-1")) },
+		"zero quote volume":   func() (*BarBuilder, error) { return NewQuoteVolumeBarBuilder(Decimal{}) },
+		"negative quote size": func() (*BarBuilder, error) { return NewQuoteVolumeBarBuilder(MustParseDecimal("-0.1")) },
+	}
+	for name, constructor := range constructors {
+		if b, err := constructor(); b != nil || !errors.Is(err, ErrInvalidBarThreshold) {
+			t.Errorf("%s: %v, %v, want ErrInvalidBarThreshold", name, b, err)
+		}
+	}
+}
+
+func TestTimeBarBuilder(t *testing.T) {
+	b, err := NewTimeBarBuilder(FixedPeriod(15 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed := addTrades(b,
+		aggTrade(1, 1000, "10", "1", false),
+		aggTrade(2, 14999, "12", "2", true),
+	)
+	if len(completed) != 0 {
+		t.Fatalf("bar completed before its end: %+v", completed[0])
+	}
+	// Trade at bucket boundary belongs to the next bar.
+	completed = addTrades(b, aggTrade(3, 15000, "11", "1", false))
+	if len(completed) != 1 {
+		t.Fatalf("%d bars completed", len(completed))
+	}
+	bar := completed[0]
+	if !bar.OpenTime.Equal(barEpoch) || !bar.CloseTime.Equal(barEpoch.Add(15*time.Second-time.Millisecond)) {
+		t.Errorf("bar covers %s - %s", bar.OpenTime, bar.CloseTime)
+	}
+	if bar.Open.String() != "10" || bar.High.String() != "12" || bar.Low.String() != "10" || bar.Close.String() != "12" ||
+		bar.Volume.String() != "3" || bar.QuoteAssetVolume.String() != "34" || bar.NumberOfTrades != 2 {
+		t.Errorf("bar = %+v", bar)
+	}
+
+	// Buckets without trades produce no bars.
+	completed = addTrades(b, aggTrade(4, 50000, "13", "1", false))
+	if len(completed) != 1 || !completed[0].OpenTime.Equal(barEpoch.Add(15*time.Second)) {
+		t.Fatalf("completed %+v", completed)
+	}
+	if partial := b.Partial(); partial == nil || !partial.OpenTime.Equal(barEpoch.Add(45*time.Second)) {
+		t.Fatalf("partial bar = %+v", partial)
+	}
+	if completed := b.AdvanceTo(barEpoch.Add(60*time.Second - time.Millisecond)); len(completed) != 0 {
+		t.Errorf("bar completed before its end: %+v", completed[0])
+	}
+	if completed := b.AdvanceTo(barEpoch.Add(60 * time.Second)); len(completed) != 1 || b.Partial() != nil {
+		t.Errorf("AdvanceTo completed %+v", completed)
+	}
+}
+
+func TestTickBarBuilder(t *testing.T) {
+	b, err := NewTickBarBuilder(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	double := aggTrade(1, 0, "10", "1", false)
+	double.LastTradeID = double.FirstTradeID + 1
+	if completed := addTrades(b, double); len(completed) != 0 {
+		t.Fatalf("bar of 2 trades completed")
+	}
+	// Aggregate trade reaching threshold closes bar, which may exceed it.
+	double = aggTrade(2, 1, "11", "1", false)
+	double.LastTradeID = double.FirstTradeID + 1
+	completed := addTrades(b, double)
+	if len(completed) != 1 || completed[0].NumberOfTrades != 4 || completed[0].Close.String() != "11" {
+		t.Fatalf("completed %+v", completed)
+	}
+	if b.Partial() != nil {
+		t.Error("new bar opened without trade")
+	}
+}
+
+func TestVolumeBarBuilder(t *testing.T) {
+	b, err := NewVolumeBarBuilder(MustParseDecimal("3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed := addTrades(b,
+		aggTrade(1, 0, "10", "1", false),
+		aggTrade(2, 1, "10", "2", false),
+		aggTrade(3, 2, "10", "2.5", false),
+	)
+	if len(completed) != 1 || completed[0].Volume.String() != "3" || !completed[0].CloseTime.Equal(barEpoch.Add(time.Millisecond)) {
+		t.Fatalf("completed %+v", completed)
+	}
+	if partial := b.Partial(); partial == nil || partial.Volume.String() != "2.5" || partial.Open.String() != "10" {
+		t.Errorf("partial bar = %+v", partial)
+	}
+
+	q, err := NewQuoteVolumeBarBuilder(MustParseDecimal("30"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed = addTrades(q,
+		aggTrade(1, 0, "10", "2", false),
+		aggTrade(2, 1, "15", "1", false),
+	)
+	if len(completed) != 1 || completed[0].QuoteAssetVolume.String() != "35" {
+		t.Fatalf("completed %+v", completed)
+	}
+}
+
+func TestBarBuilderDedupe(t *testing.T) {
+	b, err := NewTickBarBuilder(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Trade ID 0 is valid and replayed trades are ignored.
+	completed := addTrades(b,
+		aggTrade(0, 0, "10", "1", false),
+		aggTrade(0, 0, "10", "1", false),
+		aggTrade(1, 1, "11", "1", false),
+	)
+	if len(completed) != 1 || completed[0].Volume.String() != "2" {
+		t.Fatalf("completed %+v", completed)
+	}
+	// Replay across completed bar doesn't reopen it.
+	completed = addTrades(b,
+		aggTrade(0, 0, "10", "1", false),
+		aggTrade(1, 1, "11", "1", false),
+		aggTrade(2, 2, "12", "1", false),
+	)
+	if len(completed) != 0 {
+		t.Fatalf("replayed trades completed %+v", completed)
+	}
+	if partial := b.Partial(); partial == nil || partial.NumberOfTrades != 1 || partial.Open.String() != "12" {
+		t.Errorf("partial bar = %+v", partial)
+	}
+}
+
+func TestBarBuilderTakerBuyVolume(t *testing.T) {
+	b, err := NewTickBarBuilder(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed := addTrades(b,
+		// Buyer is taker.
+		aggTrade(1, 0, "10", "1", false),
+		// Seller is taker.
+		aggTrade(2, 1, "11", "2", true),
+		aggTrade(3, 2, "12", "0.5", false),
+	)
+	if len(completed) != 1 {
+		t.Fatalf("%d bars completed", len(completed))
+	}
+	bar := completed[0]
+	if bar.TakerBuyBaseAssetVolume.String() != "1.5" || bar.TakerBuyQuoteAssetVolume.String() != "16" {
+		t.Errorf("taker buy volume %s, quote %s", bar.TakerBuyBaseAssetVolume, bar.TakerBuyQuoteAssetVolume)
+	}
+	if bar.Volume.String() != "3.5" || bar.QuoteAssetVolume.String() != "38" {
+		t.Errorf("volume %s, quote %s", bar.Volume, bar.QuoteAssetVolume)
+	}
+}
@@ -2,7 +2,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	extBinanceClient "github.com/adshao/go-binance/v2"
@@ -10,76 +14,228 @@ import (
 	"go.uber.org/zap"
 )
 
+var _ binance.Client = (*Client)(nil)
+
 type Client struct {
-	ctx    context.Context
-	client *extBinanceClient.Client
-	logger *zap.Logger
+	ctx     context.Context
+	client  *extBinanceClient.Client
+	logger  *zap.Logger
+	limiter *RateLimiter
+	clock   clock
+
+	timeSyncInterval time.Duration
+
+	websocket WebsocketConfig
+	dropped   atomic.Uint64
+}
+
+// Option configures Client.
+type Option func(*Client)
+
+// WithRateLimiter makes client share given limiter, e.g. with other clients
+// using the same IP.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
 }
 
-func New(ctx context.Context, apiKey, secretKey string, logger *zap.Logger) binance.Client {
-	c := extBinanceClient.NewClient(apiKey, secretKey)
-	return &Client{
-		ctx:    ctx,
-		client: c,
-		logger: logger,
+// WithRateLimitPolicy sets policy of client's own rate limiter.
+func WithRateLimitPolicy(policy RateLimitPolicy) Option {
+	return func(c *Client) {
+		c.limiter = NewRateLimiter(policy)
 	}
 }
 
+func New(ctx context.Context, apiKey, secretKey string, logger *zap.Logger, opts ...Option) *Client {
+	c := &Client{
+		ctx:     ctx,
+		client:  extBinanceClient.NewClient(apiKey, secretKey),
+		logger:  logger,
+		limiter: NewRateLimiter(RateLimitBlock),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.client.HTTPClient = &http.Client{
+		Transport: &transport{next: http.DefaultTransport, limiter: c.limiter},
+	}
+	if c.timeSyncInterval > 0 {
+		go c.runTimeSync(ctx, c.timeSyncInterval)
+	}
+	return c
+}
+
+// RateLimiter returns limiter tracking client's request weight and order
+// rate.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
 func (c *Client) Ping(ctx context.Context) error {
-	return c.client.NewPingService().Do(ctx)
+	ctx, err := c.begin(ctx, weightPing, 0)
+	if err != nil {
+		return err
+	}
+	return convertError(ctx, c.client.NewPingService().Do(ctx))
 }
 
 func (c *Client) Time(ctx context.Context) (time.Time, error) {
+	ctx, err := c.begin(ctx, weightTime, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
 	t, err := c.client.NewServerTimeService().Do(ctx)
-	return time.UnixMilli(t), err
+	if err != nil {
+		return time.Time{}, convertError(ctx, err)
+	}
+	return time.UnixMilli(t), nil
+}
+
+func (c *Client) ExchangeInfo(ctx context.Context, eir binance.ExchangeInfoRequest) (*binance.ExchangeInfo, error) {
+	ctx, err := c.begin(ctx, weightExchangeInfo, 0)
+	if err != nil {
+		return nil, err
+	}
+	exchangeInfoService := c.client.NewExchangeInfoService()
+	switch len(eir.Symbols) {
+	case 0:
+	case 1:
+		exchangeInfoService = exchangeInfoService.Symbol(eir.Symbols[0])
+	default:
+		exchangeInfoService = exchangeInfoService.Symbols(eir.Symbols...)
+	}
+	exchangeInfo, err := exchangeInfoService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	ei, err := ConvertExchangeInfo(exchangeInfo)
+	if err != nil {
+		return nil, err
+	}
+	if len(ei.RateLimits) > 0 {
+		c.limiter.SetLimits(ei.RateLimits)
+	}
+	return ei, nil
 }
 
 func (c *Client) OrderBook(ctx context.Context, obr binance.OrderBookRequest) (*binance.OrderBook, error) {
-	depthResponse, err := c.client.NewDepthService().Symbol(obr.Symbol).Limit(obr.Limit).Do(ctx)
+	ctx, err := c.begin(ctx, orderBookWeight(obr.Limit), 0)
 	if err != nil {
 		return nil, err
 	}
+	depthResponse, err := c.client.NewDepthService().Symbol(obr.Symbol).Limit(obr.Limit).Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
 	ob := &binance.OrderBook{
 		LastUpdateID: depthResponse.LastUpdateID,
 	}
 	for _, ask := range depthResponse.Asks {
-		price, quantity, err := ask.Parse()
+		order, err := ConvertOrder(ask)
 		if err != nil {
 			return nil, err
 		}
-		ob.Asks = append(ob.Asks, &binance.Order{Price: price, Quantity: quantity})
+		ob.Asks = append(ob.Asks, order)
 	}
 	for _, bid := range depthResponse.Bids {
-		price, quantity, err := bid.Parse()
+		order, err := ConvertOrder(bid)
 		if err != nil {
 			return nil, err
 		}
-		ob.Bids = append(ob.Bids, &binance.Order{Price: price, Quantity: quantity})
+		ob.Bids = append(ob.Bids, order)
 	}
 	return ob, err
 }
 
-func (c *Client) AggTrades(context.Context, binance.AggTradesRequest) ([]*binance.AggTrade, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) AggTrades(ctx context.Context, atr binance.AggTradesRequest) ([]*binance.AggTrade, error) {
+	ctx, err := c.begin(ctx, weightAggTrades, 0)
+	if err != nil {
+		return nil, err
+	}
+	aggTradesService := c.client.NewAggTradesService().Symbol(atr.Symbol)
+	if atr.FromID > 0 {
+		aggTradesService = aggTradesService.FromID(atr.FromID)
+	}
+	if !atr.StartTime.IsZero() {
+		aggTradesService = aggTradesService.StartTime(atr.StartTime.UnixMilli())
+	}
+	if !atr.EndTime.IsZero() {
+		aggTradesService = aggTradesService.EndTime(atr.EndTime.UnixMilli())
+	}
+	if atr.Limit > 0 {
+		aggTradesService = aggTradesService.Limit(atr.Limit)
+	}
+	aggTrades, err := aggTradesService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	var trades []*binance.AggTrade
+	for _, aggTrade := range aggTrades {
+		trade, err := ConvertAggTrade(aggTrade)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert agg trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+func (c *Client) RecentTrades(ctx context.Context, rtr binance.RecentTradesRequest) ([]*binance.MarketTrade, error) {
+	ctx, err := c.begin(ctx, weightRecentTrades, 0)
+	if err != nil {
+		return nil, err
+	}
+	recentTradesService := c.client.NewRecentTradesService().Symbol(rtr.Symbol)
+	if rtr.Limit > 0 {
+		recentTradesService = recentTradesService.Limit(rtr.Limit)
+	}
+	trades, err := recentTradesService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertMarketTrades(trades)
+}
+
+func (c *Client) HistoricalTrades(ctx context.Context, htr binance.HistoricalTradesRequest) ([]*binance.MarketTrade, error) {
+	ctx, err := c.begin(ctx, weightHistoricalTrades, 0)
+	if err != nil {
+		return nil, err
+	}
+	historicalTradesService := c.client.NewHistoricalTradesService().Symbol(htr.Symbol)
+	if htr.FromID != nil {
+		historicalTradesService = historicalTradesService.FromID(*htr.FromID)
+	}
+	if htr.Limit > 0 {
+		historicalTradesService = historicalTradesService.Limit(htr.Limit)
+	}
+	trades, err := historicalTradesService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertMarketTrades(trades)
 }
 
 func (c *Client) Klines(ctx context.Context, kr binance.KlinesRequest) ([]*binance.Kline, error) {
+	ctx, err := c.begin(ctx, weightKlines, 0)
+	if err != nil {
+		return nil, err
+	}
 	klineService := c.client.NewKlinesService().
 		Symbol(kr.Symbol).
 		Interval(string(kr.Interval))
 	if kr.Limit > 0 {
 		klineService = klineService.Limit(kr.Limit)
 	}
-	if kr.StartTime.IsZero() {
-		klineService = klineService.StartTime(kr.StartTime.Unix())
+	if !kr.StartTime.IsZero() {
+		klineService = klineService.StartTime(kr.StartTime.UnixMilli())
 	}
-	if kr.EndTime > 0 {
-		klineService = klineService.StartTime(kr.EndTime)
+	if !kr.EndTime.IsZero() {
+		klineService = klineService.EndTime(kr.EndTime.UnixMilli())
 	}
 	klines, err := klineService.Do(ctx)
 	if err != nil {
-		return nil, err
+		return nil, convertError(ctx, err)
 	}
 
 	var innerKlines []*binance.Kline
@@ -93,131 +249,698 @@ func (c *Client) Klines(ctx context.Context, kr binance.KlinesRequest) ([]*binan
 	return innerKlines, nil
 }
 
-func (c *Client) Ticker24(context.Context, binance.TickerRequest) (*binance.Ticker24, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) Ticker24(ctx context.Context, tr binance.TickerRequest) ([]*binance.SymbolTicker24, error) {
+	symbols := tickerSymbols(tr.Symbol, tr.Symbols)
+	ctx, err := c.begin(ctx, ticker24Weight(len(symbols)), 0)
+	if err != nil {
+		return nil, err
+	}
+	statsService := c.client.NewListPriceChangeStatsService()
+	switch len(symbols) {
+	case 0:
+	case 1:
+		statsService = statsService.Symbol(symbols[0])
+	default:
+		statsService = statsService.Symbols(symbols)
+	}
+	stats, err := statsService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	var tickers []*binance.SymbolTicker24
+	for _, stat := range stats {
+		ticker, err := ConvertPriceChangeStats(stat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert price change stats: %w", err)
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
 }
 
-func (c *Client) TickerAllPrices(context.Context) ([]*binance.PriceTicker, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) RollingWindowTicker(ctx context.Context, rwtr binance.RollingWindowTickerRequest) ([]*binance.SymbolTicker24, error) {
+	symbols := tickerSymbols(rwtr.Symbol, rwtr.Symbols)
+	if len(symbols) == 0 {
+		return nil, binance.ErrNoSymbol
+	}
+	tickerService := c.client.NewListSymbolTickerService()
+	if rwtr.WindowSize != 0 {
+		windowSize, err := binance.FormatWindowSize(rwtr.WindowSize)
+		if err != nil {
+			return nil, err
+		}
+		tickerService = tickerService.WindowSize(windowSize)
+	}
+	ctx, err := c.begin(ctx, rollingWindowTickerWeight(len(symbols)), 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(symbols) == 1 {
+		tickerService = tickerService.Symbol(symbols[0])
+	} else {
+		tickerService = tickerService.Symbols(symbols)
+	}
+	symbolTickers, err := tickerService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	var tickers []*binance.SymbolTicker24
+	for _, symbolTicker := range symbolTickers {
+		ticker, err := ConvertSymbolTicker(symbolTicker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert symbol ticker: %w", err)
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
 }
 
-func (c *Client) TickerAllBooks(context.Context) ([]*binance.BookTicker, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) TickerAllPrices(ctx context.Context, tr binance.TickerRequest) ([]*binance.PriceTicker, error) {
+	symbols := tickerSymbols(tr.Symbol, tr.Symbols)
+	ctx, err := c.begin(ctx, symbolTickerWeight(len(symbols)), 0)
+	if err != nil {
+		return nil, err
+	}
+	pricesService := c.client.NewListPricesService()
+	switch len(symbols) {
+	case 0:
+	case 1:
+		pricesService = pricesService.Symbol(symbols[0])
+	default:
+		pricesService = pricesService.Symbols(symbols)
+	}
+	prices, err := pricesService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	var tickers []*binance.PriceTicker
+	for _, price := range prices {
+		ticker, err := ConvertSymbolPrice(price)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert symbol price: %w", err)
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
 }
 
-func (c *Client) NewOrder(context.Context, binance.NewOrderRequest) (*binance.ProcessedOrder, error) {
-	//TODO implement me
-	panic("implement me")
+// TickerAllBooks returns book tickers. Several symbols are filtered out of
+// all book tickers, as the underlying client can't request them at once.
+func (c *Client) TickerAllBooks(ctx context.Context, tr binance.TickerRequest) ([]*binance.BookTicker, error) {
+	symbols := tickerSymbols(tr.Symbol, tr.Symbols)
+	ctx, err := c.begin(ctx, symbolTickerWeight(len(symbols)), 0)
+	if err != nil {
+		return nil, err
+	}
+	bookTickersService := c.client.NewListBookTickersService()
+	if len(symbols) == 1 {
+		bookTickersService = bookTickersService.Symbol(symbols[0])
+	}
+	bookTickers, err := bookTickersService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	requested := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		requested[symbol] = true
+	}
+	var tickers []*binance.BookTicker
+	for _, bookTicker := range bookTickers {
+		if len(symbols) > 1 && !requested[bookTicker.Symbol] {
+			continue
+		}
+		ticker, err := ConvertBookTicker(bookTicker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert book ticker: %w", err)
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
 }
 
-func (c *Client) NewOrderTest(context.Context, binance.NewOrderRequest) error {
-	//TODO implement me
-	panic("implement me")
+// tickerSymbols merges Symbol and Symbols of ticker request.
+func tickerSymbols(symbol string, symbols []string) []string {
+	if symbol == "" {
+		return symbols
+	}
+	return append([]string{symbol}, symbols...)
 }
 
-func (c *Client) QueryOrder(_ context.Context, _ binance.QueryOrderRequest) (*binance.ExecutedOrder, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) NewOrder(ctx context.Context, nor binance.NewOrderRequest) (*binance.ProcessedOrder, error) {
+	if err := binance.CheckOrderRequest(nor); err != nil {
+		return nil, err
+	}
+	ctx, err := c.begin(ctx, weightNewOrder, ordersPerNewOrderRequest)
+	if err != nil {
+		return nil, err
+	}
+	orderService := ConvertNewOrderRequest(c.signed(nor.Timestamp).NewCreateOrderService(), nor)
+	order, err := orderService.Do(ctx, recvWindowOptions(nor.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	processedOrder, err := ConvertProcessedOrder(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert processed order: %w", err)
+	}
+	return processedOrder, nil
 }
 
-func (c *Client) CancelOrder(_ context.Context, _ binance.CancelOrderRequest) (*binance.CanceledOrder, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) NewOrderTest(ctx context.Context, nor binance.NewOrderRequest) error {
+	if err := binance.CheckOrderRequest(nor); err != nil {
+		return err
+	}
+	ctx, err := c.begin(ctx, weightNewOrder, 0)
+	if err != nil {
+		return err
+	}
+	orderService := ConvertNewOrderRequest(c.signed(nor.Timestamp).NewCreateOrderService(), nor)
+	return convertError(ctx, orderService.Test(ctx, recvWindowOptions(nor.RecvWindow)...))
 }
 
-func (c *Client) OpenOrders(_ context.Context, _ binance.OpenOrdersRequest) ([]*binance.ExecutedOrder, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) QueryOrder(ctx context.Context, qor binance.QueryOrderRequest) (*binance.ExecutedOrder, error) {
+	ctx, err := c.begin(ctx, weightQueryOrder, 0)
+	if err != nil {
+		return nil, err
+	}
+	orderService := c.signed(qor.Timestamp).NewGetOrderService().Symbol(qor.Symbol)
+	if qor.OrderID > 0 {
+		orderService = orderService.OrderID(qor.OrderID)
+	}
+	if qor.OrigClientOrderID != "" {
+		orderService = orderService.OrigClientOrderID(qor.OrigClientOrderID)
+	}
+	order, err := orderService.Do(ctx, recvWindowOptions(qor.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertExecutedOrder(order)
 }
 
-func (c *Client) AllOrders(_ context.Context, _ binance.AllOrdersRequest) ([]*binance.ExecutedOrder, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) CancelOrder(ctx context.Context, cor binance.CancelOrderRequest) (*binance.CanceledOrder, error) {
+	ctx, err := c.begin(ctx, weightCancelOrder, 0)
+	if err != nil {
+		return nil, err
+	}
+	cancelService := c.signed(cor.Timestamp).NewCancelOrderService().Symbol(cor.Symbol)
+	if cor.OrderID > 0 {
+		cancelService = cancelService.OrderID(cor.OrderID)
+	}
+	if cor.OrigClientOrderID != "" {
+		cancelService = cancelService.OrigClientOrderID(cor.OrigClientOrderID)
+	}
+	if cor.NewClientOrderID != "" {
+		cancelService = cancelService.NewClientOrderID(cor.NewClientOrderID)
+	}
+	order, err := cancelService.Do(ctx, recvWindowOptions(cor.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertCanceledOrder(order), nil
 }
 
-func (c *Client) Account(_ context.Context, _ binance.AccountRequest) (*binance.Account, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) OpenOrders(ctx context.Context, oor binance.OpenOrdersRequest) ([]*binance.ExecutedOrder, error) {
+	weight := weightOpenOrdersSymbol
+	if oor.Symbol == "" {
+		weight = weightOpenOrdersAll
+	}
+	ctx, err := c.begin(ctx, weight, 0)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := c.signed(oor.Timestamp).NewListOpenOrdersService().
+		Symbol(oor.Symbol).
+		Do(ctx, recvWindowOptions(oor.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertExecutedOrders(orders)
 }
 
-func (c *Client) MyTrades(_ context.Context, _ binance.MyTradesRequest) ([]*binance.Trade, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) AllOrders(ctx context.Context, aor binance.AllOrdersRequest) ([]*binance.ExecutedOrder, error) {
+	ctx, err := c.begin(ctx, weightAllOrders, 0)
+	if err != nil {
+		return nil, err
+	}
+	ordersService := c.signed(aor.Timestamp).NewListOrdersService().Symbol(aor.Symbol)
+	if aor.OrderID > 0 {
+		ordersService = ordersService.OrderID(aor.OrderID)
+	}
+	if aor.Limit > 0 {
+		ordersService = ordersService.Limit(aor.Limit)
+	}
+	orders, err := ordersService.Do(ctx, recvWindowOptions(aor.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertExecutedOrders(orders)
 }
 
-func (c *Client) Withdraw(_ context.Context, _ binance.WithdrawRequest) (*binance.WithdrawResult, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) Account(ctx context.Context, ar binance.AccountRequest) (*binance.Account, error) {
+	ctx, err := c.begin(ctx, weightAccount, 0)
+	if err != nil {
+		return nil, err
+	}
+	account, err := c.signed(ar.Timestamp).NewGetAccountService().Do(ctx, recvWindowOptions(ar.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return ConvertAccount(account)
 }
 
-func (c *Client) DepositHistory(_ context.Context, _ binance.HistoryRequest) ([]*binance.Deposit, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) MyTrades(ctx context.Context, mtr binance.MyTradesRequest) ([]*binance.Trade, error) {
+	ctx, err := c.begin(ctx, weightMyTrades, 0)
+	if err != nil {
+		return nil, err
+	}
+	tradesService := c.signed(mtr.Timestamp).NewListTradesService().Symbol(mtr.Symbol)
+	if mtr.Limit > 0 {
+		tradesService = tradesService.Limit(mtr.Limit)
+	}
+	if mtr.FromID > 0 {
+		tradesService = tradesService.FromID(mtr.FromID)
+	}
+	trades, err := tradesService.Do(ctx, recvWindowOptions(mtr.RecvWindow)...)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	innerTrades := make([]*binance.Trade, 0, len(trades))
+	for _, trade := range trades {
+		innerTrade, err := ConvertTrade(trade)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert trade %d: %w", trade.ID, err)
+		}
+		innerTrades = append(innerTrades, innerTrade)
+	}
+	return innerTrades, nil
 }
 
-func (c *Client) WithdrawHistory(_ context.Context, _ binance.HistoryRequest) ([]*binance.Withdrawal, error) {
-	//TODO implement me
-	panic("implement me")
+// Withdraw executes withdrawal. Underlying service doesn't accept request
+// options, so wr.RecvWindow is ignored. SAPI endpoints have own limits and
+// aren't tracked by RateLimiter.
+func (c *Client) Withdraw(ctx context.Context, wr binance.WithdrawRequest) (*binance.WithdrawResult, error) {
+	ctx, _ = withResponseRecorder(ctx)
+	withdrawService := c.signed(wr.Timestamp).NewCreateWithdrawService().
+		Coin(wr.Asset).
+		Address(wr.Address).
+		Amount(wr.Amount.String())
+	if wr.Name != "" {
+		withdrawService = withdrawService.Name(wr.Name)
+	}
+	res, err := withdrawService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return &binance.WithdrawResult{ID: res.ID, Success: true}, nil
 }
 
-func (c *Client) StartUserDataStream(_ context.Context) (*binance.Stream, error) {
-	//TODO implement me
-	panic("implement me")
+// DepositHistory lists deposit data. Underlying service doesn't accept
+// request options, so hr.RecvWindow is ignored.
+func (c *Client) DepositHistory(ctx context.Context, hr binance.HistoryRequest) ([]*binance.Deposit, error) {
+	ctx, _ = withResponseRecorder(ctx)
+	depositsService := c.signed(hr.Timestamp).NewListDepositsService()
+	if hr.Asset != "" {
+		depositsService = depositsService.Coin(hr.Asset)
+	}
+	if hr.Status != nil {
+		depositsService = depositsService.Status(*hr.Status)
+	}
+	if !hr.StartTime.IsZero() {
+		depositsService = depositsService.StartTime(hr.StartTime.UnixMilli())
+	}
+	if !hr.EndTime.IsZero() {
+		depositsService = depositsService.EndTime(hr.EndTime.UnixMilli())
+	}
+	deposits, err := depositsService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	innerDeposits := make([]*binance.Deposit, 0, len(deposits))
+	for _, deposit := range deposits {
+		innerDeposit, err := ConvertDeposit(deposit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert deposit %s: %w", deposit.TxID, err)
+		}
+		innerDeposits = append(innerDeposits, innerDeposit)
+	}
+	return innerDeposits, nil
 }
 
-func (c *Client) KeepAliveUserDataStream(_ context.Context, _ *binance.Stream) error {
-	//TODO implement me
-	panic("implement me")
+// WithdrawHistory lists withdraw data. Underlying service doesn't accept
+// request options, so hr.RecvWindow is ignored.
+func (c *Client) WithdrawHistory(ctx context.Context, hr binance.HistoryRequest) ([]*binance.Withdrawal, error) {
+	ctx, _ = withResponseRecorder(ctx)
+	withdrawsService := c.signed(hr.Timestamp).NewListWithdrawsService()
+	if hr.Asset != "" {
+		withdrawsService = withdrawsService.Coin(hr.Asset)
+	}
+	if hr.Status != nil {
+		withdrawsService = withdrawsService.Status(*hr.Status)
+	}
+	if !hr.StartTime.IsZero() {
+		withdrawsService = withdrawsService.StartTime(hr.StartTime.UnixMilli())
+	}
+	if !hr.EndTime.IsZero() {
+		withdrawsService = withdrawsService.EndTime(hr.EndTime.UnixMilli())
+	}
+	withdraws, err := withdrawsService.Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	withdrawals := make([]*binance.Withdrawal, 0, len(withdraws))
+	for _, withdraw := range withdraws {
+		withdrawal, err := ConvertWithdrawal(withdraw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert withdrawal %s: %w", withdraw.ID, err)
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+	return withdrawals, nil
 }
 
-func (c *Client) CloseUserDataStream(_ context.Context, _ *binance.Stream) error {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) StartUserDataStream(ctx context.Context) (*binance.Stream, error) {
+	ctx, err := c.begin(ctx, weightUserDataStream, 0)
+	if err != nil {
+		return nil, err
+	}
+	listenKey, err := c.client.NewStartUserStreamService().Do(ctx)
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+	return &binance.Stream{ListenKey: listenKey}, nil
 }
 
-func (c *Client) DepthWebsocket(_ context.Context, _ binance.DepthWebsocketRequest) (chan *binance.DepthEvent, chan struct{}, error) {
-	//TODO implement me
-	panic("implement me")
+func (c *Client) KeepAliveUserDataStream(ctx context.Context, s *binance.Stream) error {
+	ctx, err := c.begin(ctx, weightUserDataStream, 0)
+	if err != nil {
+		return err
+	}
+	return convertError(ctx, c.client.NewKeepaliveUserStreamService().ListenKey(s.ListenKey).Do(ctx))
+}
+
+func (c *Client) CloseUserDataStream(ctx context.Context, s *binance.Stream) error {
+	ctx, err := c.begin(ctx, weightUserDataStream, 0)
+	if err != nil {
+		return err
+	}
+	return convertError(ctx, c.client.NewCloseUserStreamService().ListenKey(s.ListenKey).Do(ctx))
+}
+
+func (c *Client) DepthWebsocket(ctx context.Context, dwr binance.DepthWebsocketRequest) (chan *binance.DepthEvent, chan struct{}, error) {
+	serve := extBinanceClient.WsDepthServe
+	if dwr.UpdateSpeed > 0 && dwr.UpdateSpeed < time.Second {
+		serve = extBinanceClient.WsDepthServe100Ms
+	}
+	buffer := newEventBuffer[*binance.DepthEvent](c.websocket, &c.dropped, nil)
+	doneC, stopC, err := serve(dwr.Symbol,
+		func(event *extBinanceClient.WsDepthEvent) {
+			convertedEvent, err := ConvertWSDepthEvent(event)
+			if err != nil {
+				c.logger.Error("failed to convert ws depth event", zap.Error(err))
+				return
+			}
+			buffer.push(ctx, convertedEvent)
+		},
+		func(err error) {
+			c.logger.Error("depth websocket error", zap.Error(err))
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
 }
 
 func (c *Client) KlineWebsocket(ctx context.Context, kwr binance.KlineWebsocketRequest) (chan *binance.KlineEvent, chan struct{}, error) {
-	events := make(chan *binance.KlineEvent)
+	buffer := newEventBuffer(c.websocket, &c.dropped, klineEventKey)
 	doneC, stopC, err := extBinanceClient.WsKlineServe(kwr.Symbol, string(kwr.Interval),
 		func(event *extBinanceClient.WsKlineEvent) {
 			convertedEvent, err := ConvertWSKlineEvent(event)
 			if err != nil {
 				c.logger.Error("failed to convert ws kline event", zap.Error(err))
-			} else {
-				events <- convertedEvent
+				return
 			}
+			buffer.push(ctx, convertedEvent)
 		},
 		func(err error) {
 			c.logger.Error("kline websocket error", zap.Error(err))
 		},
 	)
-	go func() {
-		<-doneC
-		close(events)
-	}()
-	go func() {
-		<-ctx.Done()
-		stopC <- struct{}{}
-	}()
 	if err != nil {
 		return nil, nil, err
 	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
+}
+
+func (c *Client) TradeWebsocket(ctx context.Context, twr binance.TradeWebsocketRequest) (chan *binance.AggTradeEvent, chan struct{}, error) {
+	buffer := newEventBuffer[*binance.AggTradeEvent](c.websocket, &c.dropped, nil)
+	doneC, stopC, err := extBinanceClient.WsAggTradeServe(twr.Symbol,
+		func(event *extBinanceClient.WsAggTradeEvent) {
+			convertedEvent, err := ConvertWSAggTradeEvent(event)
+			if err != nil {
+				c.logger.Error("failed to convert ws agg trade event", zap.Error(err))
+				return
+			}
+			buffer.push(ctx, convertedEvent)
+		},
+		func(err error) {
+			c.logger.Error("trade websocket error", zap.Error(err))
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
+}
+
+// RawTradeWebsocket serves individual trade events, unlike TradeWebsocket
+// serving aggregate ones.
+func (c *Client) RawTradeWebsocket(ctx context.Context, twr binance.TradeWebsocketRequest) (chan *binance.MarketTradeEvent, chan struct{}, error) {
+	buffer := newEventBuffer[*binance.MarketTradeEvent](c.websocket, &c.dropped, nil)
+	doneC, stopC, err := extBinanceClient.WsTradeServe(twr.Symbol,
+		func(event *extBinanceClient.WsTradeEvent) {
+			convertedEvent, err := ConvertWSTradeEvent(event)
+			if err != nil {
+				c.logger.Error("failed to convert ws trade event", zap.Error(err))
+				return
+			}
+			buffer.push(ctx, convertedEvent)
+		},
+		func(err error) {
+			c.logger.Error("raw trade websocket error", zap.Error(err))
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
+}
+
+// TickerWebsocket serves 24hr ticker events of symbol, or of all symbols
+// changed within last second when Symbol is empty.
+func (c *Client) TickerWebsocket(ctx context.Context, twr binance.TickerWebsocketRequest) (chan *binance.Ticker24Event, chan struct{}, error) {
+	buffer := newEventBuffer(c.websocket, &c.dropped, ticker24EventKey)
+	handler := func(event *extBinanceClient.WsMarketStatEvent) {
+		convertedEvent, err := ConvertWSMarketStatEvent(event)
+		if err != nil {
+			c.logger.Error("failed to convert ws market stat event", zap.Error(err))
+			return
+		}
+		buffer.push(ctx, convertedEvent)
+	}
+	errHandler := func(err error) {
+		c.logger.Error("ticker websocket error", zap.Error(err))
+	}
+	var doneC, stopC chan struct{}
+	var err error
+	if twr.Symbol == "" {
+		doneC, stopC, err = extBinanceClient.WsAllMarketsStatServe(
+			func(events extBinanceClient.WsAllMarketsStatEvent) {
+				for _, event := range events {
+					handler(event)
+				}
+			}, errHandler)
+	} else {
+		doneC, stopC, err = extBinanceClient.WsMarketStatServe(twr.Symbol, handler, errHandler)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
 	return events, doneC, nil
 }
 
-func (c *Client) TradeWebsocket(_ context.Context, _ binance.TradeWebsocketRequest) (chan *binance.AggTradeEvent, chan struct{}, error) {
-	//TODO implement me
-	panic("implement me")
+// MiniTickerWebsocket serves 24hr mini ticker events of symbol, or of all
+// symbols changed within last second when Symbol is empty.
+func (c *Client) MiniTickerWebsocket(ctx context.Context, twr binance.TickerWebsocketRequest) (chan *binance.MiniTickerEvent, chan struct{}, error) {
+	buffer := newEventBuffer(c.websocket, &c.dropped, miniTickerEventKey)
+	handler := func(event *extBinanceClient.WsMiniMarketsStatEvent) {
+		convertedEvent, err := ConvertWSMiniMarketStatEvent(event)
+		if err != nil {
+			c.logger.Error("failed to convert ws mini market stat event", zap.Error(err))
+			return
+		}
+		buffer.push(ctx, convertedEvent)
+	}
+	errHandler := func(err error) {
+		c.logger.Error("mini ticker websocket error", zap.Error(err))
+	}
+	var doneC, stopC chan struct{}
+	var err error
+	if twr.Symbol == "" {
+		doneC, stopC, err = extBinanceClient.WsAllMiniMarketsStatServe(
+			func(events extBinanceClient.WsAllMiniMarketsStatEvent) {
+				for _, event := range events {
+					handler(event)
+				}
+			}, errHandler)
+	} else {
+		doneC, stopC, err = serveStream(strings.ToLower(twr.Symbol)+"@miniTicker",
+			func(message []byte) {
+				event := new(extBinanceClient.WsMiniMarketsStatEvent)
+				if err := json.Unmarshal(message, event); err != nil {
+					errHandler(err)
+					return
+				}
+				handler(event)
+			}, errHandler)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
 }
 
-func (c *Client) UserDataWebsocket(_ context.Context, _ binance.UserDataWebsocketRequest) (chan *binance.AccountEvent, chan struct{}, error) {
-	//TODO implement me
-	panic("implement me")
+// BookTickerWebsocket serves best price and quantity updates of symbol. It
+// returns binance.ErrNoSymbol when Symbol is empty.
+func (c *Client) BookTickerWebsocket(ctx context.Context, twr binance.TickerWebsocketRequest) (chan *binance.BookTickerEvent, chan struct{}, error) {
+	if twr.Symbol == "" {
+		return nil, nil, binance.ErrNoSymbol
+	}
+	buffer := newEventBuffer(c.websocket, &c.dropped, bookTickerEventKey)
+	handler := func(event *extBinanceClient.WsBookTickerEvent) {
+		convertedEvent, err := ConvertWSBookTickerEvent(event)
+		if err != nil {
+			c.logger.Error("failed to convert ws book ticker event", zap.Error(err))
+			return
+		}
+		buffer.push(ctx, convertedEvent)
+	}
+	errHandler := func(err error) {
+		c.logger.Error("book ticker websocket error", zap.Error(err))
+	}
+	doneC, stopC, err := extBinanceClient.WsBookTickerServe(twr.Symbol, handler, errHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
+}
+
+// UserDataWebsocket serves user data events of given listen key. When
+// ListenKey is empty, listen key is obtained and kept alive by
+// UserDataSession until ctx is done.
+func (c *Client) UserDataWebsocket(ctx context.Context, udwr binance.UserDataWebsocketRequest) (chan *binance.UserDataEvent, chan struct{}, error) {
+	if udwr.ListenKey == "" {
+		return NewUserDataSession(c, udwr.KeepAliveInterval).Start(ctx)
+	}
+	buffer := newEventBuffer[*binance.UserDataEvent](c.websocket, &c.dropped, nil)
+	doneC, stopC, err := c.serveUserData(udwr.ListenKey, func(event *extBinanceClient.WsUserDataEvent) {
+		forwardUserDataEvent(ctx, c.logger, buffer, event)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	stopOnDone(ctx, stopC, doneC)
+	events, doneC := buffer.forward(ctx, doneC)
+	return events, doneC, nil
+}
+
+func (c *Client) serveUserData(listenKey string, handler extBinanceClient.WsUserDataHandler) (chan struct{}, chan struct{}, error) {
+	return extBinanceClient.WsUserDataServe(listenKey, handler,
+		func(err error) {
+			c.logger.Error("user data websocket error", zap.Error(err))
+		},
+	)
+}
+
+func forwardUserDataEvent(ctx context.Context, logger *zap.Logger, buffer *eventBuffer[*binance.UserDataEvent], event *extBinanceClient.WsUserDataEvent) {
+	switch event.Event {
+	case extBinanceClient.UserDataEventTypeOutboundAccountPosition,
+		extBinanceClient.UserDataEventTypeBalanceUpdate,
+		extBinanceClient.UserDataEventTypeExecutionReport:
+	default:
+		return
+	}
+	convertedEvent, err := ConvertWSUserDataEvent(event)
+	if err != nil {
+		logger.Error("failed to convert ws user data event", zap.Error(err))
+		return
+	}
+	buffer.push(ctx, convertedEvent)
+}
+
+// begin waits until request fits into rate limits and returns context
+// recording response status for convertError.
+func (c *Client) begin(ctx context.Context, weight, orders int) (context.Context, error) {
+	if err := c.limiter.Wait(ctx, weight, orders); err != nil {
+		return ctx, err
+	}
+	ctx, _ = withResponseRecorder(ctx)
+	return ctx, nil
+}
+
+// convertError converts error of request made with ctx, adding recorded
+// response status and Retry-After.
+func convertError(ctx context.Context, err error) error {
+	recorder, ok := ctx.Value(responseRecorderKey{}).(*responseRecorder)
+	if !ok {
+		return ConvertError(err)
+	}
+	err = ConvertErrorWithStatus(err, recorder.statusCode)
+	if apiErr, ok := err.(binance.Error); ok {
+		apiErr.RetryAfter = recorder.retryAfter
+		return apiErr
+	}
+	return err
+}
+
+// signed returns underlying client which stamps signed requests with ts
+// instead of local clock. Zero ts means local clock corrected by measured
+// drift.
+func (c *Client) signed(ts time.Time) *extBinanceClient.Client {
+	var timeOffset int64
+	if ts.IsZero() {
+		timeOffset = -c.clock.get().Offset.Milliseconds()
+	} else {
+		timeOffset = time.Now().UnixMilli() - ts.UnixMilli()
+	}
+	if timeOffset == 0 {
+		return c.client
+	}
+	signedClient := *c.client
+	signedClient.TimeOffset = timeOffset
+	return &signedClient
+}
+
+func recvWindowOptions(recvWindow time.Duration) []extBinanceClient.RequestOption {
+	if recvWindow <= 0 {
+		return nil
+	}
+	return []extBinanceClient.RequestOption{extBinanceClient.WithRecvWindow(recvWindow.Milliseconds())}
 }
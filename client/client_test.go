@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	extBinanceClient "github.com/adshao/go-binance/v2"
+	"github.com/asnowflake777/go-binance"
+	"go.uber.org/zap"
+)
+
+func TestNewOrderChecksRequest(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	apiURL := extBinanceClient.BaseAPIMainURL
+	extBinanceClient.BaseAPIMainURL = srv.URL
+	defer func() { extBinanceClient.BaseAPIMainURL = apiURL }()
+
+	c := New(context.Background(), "", "", zap.NewNop())
+	nor := binance.NewOrderRequest{
+		Symbol:      "BTCUSDT",
+		Side:        binance.SideBuy,
+		Type:        binance.TypeLimit,
+		TimeInForce: binance.IOC,
+		Price:       binance.MustParseDecimal("20"),
+		Quantity:    binance.MustParseDecimal("1"),
+		IcebergQty:  binance.MustParseDecimal("0.1"),
+	}
+	var verr *binance.ValidationError
+	if _, err := c.NewOrder(context.Background(), nor); !errors.As(err, &verr) || verr.Field != "icebergQty" {
+		t.Errorf("NewOrder: %v", err)
+	}
+	nor.IcebergQty = binance.Decimal{}
+	nor.TimeInForce = ""
+	if err := c.NewOrderTest(context.Background(), nor); !errors.As(err, &verr) || verr.Field != "timeInForce" {
+		t.Errorf("NewOrderTest: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+}
@@ -1,56 +1,78 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	externalClient "github.com/adshao/go-binance/v2"
+	externalCommon "github.com/adshao/go-binance/v2/common"
 	"github.com/asnowflake777/go-binance"
 )
 
+// ConvertError turns API rejections into binance.Error. Other errors are
+// returned unchanged.
+func ConvertError(err error) error {
+	return ConvertErrorWithStatus(err, 0)
+}
+
+// ConvertErrorWithStatus is like ConvertError but also sets HTTP status of
+// response which carried the rejection.
+func ConvertErrorWithStatus(err error, statusCode int) error {
+	var apiErr *externalCommon.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if !apiErr.IsValid() {
+		return binance.Error{Message: string(apiErr.Response), HTTPStatus: statusCode}
+	}
+	return binance.Error{Code: int(apiErr.Code), Message: apiErr.Message, HTTPStatus: statusCode}
+}
+
 func ConvertKline(kline *externalClient.Kline) (*binance.Kline, error) {
-	open, err := strconv.ParseFloat(kline.Open, 64)
+	open, err := parseDecimal("open", kline.Open)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	high, err := strconv.ParseFloat(kline.High, 64)
+	high, err := parseDecimal("high", kline.High)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	low, err := strconv.ParseFloat(kline.Low, 64)
+	low, err := parseDecimal("low", kline.Low)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	closed, err := strconv.ParseFloat(kline.Close, 64)
+	closed, err := parseDecimal("close", kline.Close)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	volume, err := strconv.ParseFloat(kline.Volume, 64)
+	volume, err := parseDecimal("volume", kline.Volume)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	quoteAssetValume, err := strconv.ParseFloat(kline.QuoteAssetVolume, 64)
+	quoteAssetValume, err := parseDecimal("quoteAssetVolume", kline.QuoteAssetVolume)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	takerBuyBaseAssetVolume, err := strconv.ParseFloat(kline.TakerBuyBaseAssetVolume, 64)
+	takerBuyBaseAssetVolume, err := parseDecimal("takerBuyBaseAssetVolume", kline.TakerBuyBaseAssetVolume)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
-	takerBuyQuoteAssetVolume, err := strconv.ParseFloat(kline.TakerBuyQuoteAssetVolume, 64)
+	takerBuyQuoteAssetVolume, err := parseDecimal("takerBuyQuoteAssetVolume", kline.TakerBuyQuoteAssetVolume)
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, err
 	}
 	k := &binance.Kline{
-		OpenTime:                 time.UnixMicro(kline.OpenTime),
+		OpenTime:                 time.UnixMilli(kline.OpenTime),
 		Open:                     open,
 		High:                     high,
 		Low:                      low,
 		Close:                    closed,
 		Volume:                   volume,
-		CloseTime:                time.UnixMicro(kline.CloseTime),
+		CloseTime:                time.UnixMilli(kline.CloseTime),
 		QuoteAssetVolume:         quoteAssetValume,
+		NumberOfTrades:           int(kline.TradeNum),
 		TakerBuyBaseAssetVolume:  takerBuyBaseAssetVolume,
 		TakerBuyQuoteAssetVolume: takerBuyQuoteAssetVolume,
 	}
@@ -94,3 +116,1073 @@ func ConvertWSKline(wsKline *externalClient.WsKline) (*binance.Kline, error) {
 	convertedKline, err := ConvertKline(kline)
 	return convertedKline, err
 }
+
+func ConvertNewOrderRequest(s *externalClient.CreateOrderService, nor binance.NewOrderRequest) *externalClient.CreateOrderService {
+	s = s.Symbol(nor.Symbol).
+		Side(externalClient.SideType(nor.Side)).
+		Type(externalClient.OrderType(nor.Type))
+	if nor.TimeInForce != "" {
+		s = s.TimeInForce(externalClient.TimeInForceType(nor.TimeInForce))
+	}
+	if nor.Quantity.Sign() > 0 {
+		s = s.Quantity(nor.Quantity.String())
+	}
+	if nor.QuoteOrderQty.Sign() > 0 {
+		s = s.QuoteOrderQty(nor.QuoteOrderQty.String())
+	}
+	if nor.Price.Sign() > 0 {
+		s = s.Price(nor.Price.String())
+	}
+	if nor.NewClientOrderID != "" {
+		s = s.NewClientOrderID(nor.NewClientOrderID)
+	}
+	if nor.StopPrice.Sign() > 0 {
+		s = s.StopPrice(nor.StopPrice.String())
+	}
+	if nor.TrailingDelta > 0 {
+		s = s.TrailingDelta(strconv.Itoa(nor.TrailingDelta))
+	}
+	if nor.IcebergQty.Sign() > 0 {
+		s = s.IcebergQuantity(nor.IcebergQty.String())
+	}
+	if nor.NewOrderRespType != "" {
+		s = s.NewOrderRespType(externalClient.NewOrderRespType(nor.NewOrderRespType))
+	}
+	return s
+}
+
+func ConvertOrder(level externalCommon.PriceLevel) (*binance.Order, error) {
+	price, err := parseDecimal("price", level.Price)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := parseDecimal("quantity", level.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	return &binance.Order{Price: price, Quantity: quantity}, nil
+}
+
+func ConvertWSDepthEvent(event *externalClient.WsDepthEvent) (*binance.DepthEvent, error) {
+	depthEvent := &binance.DepthEvent{
+		WSEvent: binance.WSEvent{
+			Type:   event.Event,
+			Time:   time.UnixMilli(event.Time),
+			Symbol: event.Symbol,
+		},
+		FirstUpdateID: event.FirstUpdateID,
+		OrderBook: binance.OrderBook{
+			LastUpdateID: event.LastUpdateID,
+		},
+	}
+	for _, bid := range event.Bids {
+		order, err := ConvertOrder(bid)
+		if err != nil {
+			return nil, err
+		}
+		depthEvent.Bids = append(depthEvent.Bids, order)
+	}
+	for _, ask := range event.Asks {
+		order, err := ConvertOrder(ask)
+		if err != nil {
+			return nil, err
+		}
+		depthEvent.Asks = append(depthEvent.Asks, order)
+	}
+	return depthEvent, nil
+}
+
+func ConvertProcessedOrder(order *externalClient.CreateOrderResponse) (*binance.ProcessedOrder, error) {
+	price, err := parseDecimal("price", order.Price)
+	if err != nil {
+		return nil, err
+	}
+	origQty, err := parseDecimal("origQty", order.OrigQuantity)
+	if err != nil {
+		return nil, err
+	}
+	executedQty, err := parseDecimal("executedQty", order.ExecutedQuantity)
+	if err != nil {
+		return nil, err
+	}
+	cummulativeQuoteQty, err := parseDecimal("cummulativeQuoteQty", order.CummulativeQuoteQuantity)
+	if err != nil {
+		return nil, err
+	}
+	processedOrder := &binance.ProcessedOrder{
+		Symbol:              order.Symbol,
+		OrderID:             order.OrderID,
+		ClientOrderID:       order.ClientOrderID,
+		TransactTime:        time.UnixMilli(order.TransactTime),
+		Price:               price,
+		OrigQty:             origQty,
+		ExecutedQty:         executedQty,
+		CummulativeQuoteQty: cummulativeQuoteQty,
+		Status:              binance.OrderStatus(order.Status),
+		TimeInForce:         binance.TimeInForce(order.TimeInForce),
+		Type:                binance.OrderType(order.Type),
+		Side:                binance.OrderSide(order.Side),
+	}
+	for _, fill := range order.Fills {
+		convertedFill, err := ConvertFill(fill)
+		if err != nil {
+			return nil, err
+		}
+		processedOrder.Fills = append(processedOrder.Fills, convertedFill)
+	}
+	return processedOrder, nil
+}
+
+func ConvertFill(fill *externalClient.Fill) (*binance.Fill, error) {
+	price, err := parseDecimal("price", fill.Price)
+	if err != nil {
+		return nil, err
+	}
+	qty, err := parseDecimal("qty", fill.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	commission, err := parseDecimal("commission", fill.Commission)
+	if err != nil {
+		return nil, err
+	}
+	return &binance.Fill{
+		TradeID:         fill.TradeID,
+		Price:           price,
+		Qty:             qty,
+		Commission:      commission,
+		CommissionAsset: fill.CommissionAsset,
+	}, nil
+}
+
+func ConvertExecutedOrder(order *externalClient.Order) (*binance.ExecutedOrder, error) {
+	price, err := parseDecimal("price", order.Price)
+	if err != nil {
+		return nil, err
+	}
+	origQty, err := parseDecimal("origQty", order.OrigQuantity)
+	if err != nil {
+		return nil, err
+	}
+	executedQty, err := parseDecimal("executedQty", order.ExecutedQuantity)
+	if err != nil {
+		return nil, err
+	}
+	cummulativeQuoteQty, err := parseDecimal("cummulativeQuoteQty", order.CummulativeQuoteQuantity)
+	if err != nil {
+		return nil, err
+	}
+	stopPrice, err := parseDecimal("stopPrice", order.StopPrice)
+	if err != nil {
+		return nil, err
+	}
+	icebergQty, err := parseDecimal("icebergQty", order.IcebergQuantity)
+	if err != nil {
+		return nil, err
+	}
+	eo := &binance.ExecutedOrder{
+		Symbol:              order.Symbol,
+		OrderID:             int(order.OrderID),
+		ClientOrderID:       order.ClientOrderID,
+		Price:               price,
+		OrigQty:             origQty,
+		ExecutedQty:         executedQty,
+		CummulativeQuoteQty: cummulativeQuoteQty,
+		Status:              binance.OrderStatus(order.Status),
+		TimeInForce:         binance.TimeInForce(order.TimeInForce),
+		Type:                binance.OrderType(order.Type),
+		Side:                binance.OrderSide(order.Side),
+		StopPrice:           stopPrice,
+		IcebergQty:          icebergQty,
+		Time:                time.UnixMilli(order.Time),
+	}
+	return eo, nil
+}
+
+func ConvertExecutedOrders(orders []*externalClient.Order) ([]*binance.ExecutedOrder, error) {
+	executedOrders := make([]*binance.ExecutedOrder, 0, len(orders))
+	for _, order := range orders {
+		executedOrder, err := ConvertExecutedOrder(order)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert order %d: %w", order.OrderID, err)
+		}
+		executedOrders = append(executedOrders, executedOrder)
+	}
+	return executedOrders, nil
+}
+
+func ConvertCanceledOrder(order *externalClient.CancelOrderResponse) *binance.CanceledOrder {
+	return &binance.CanceledOrder{
+		Symbol:            order.Symbol,
+		OrigClientOrderID: order.OrigClientOrderID,
+		OrderID:           order.OrderID,
+		ClientOrderID:     order.ClientOrderID,
+	}
+}
+
+func ConvertAccount(account *externalClient.Account) (*binance.Account, error) {
+	a := &binance.Account{
+		MakerCommision:  account.MakerCommission,
+		TakerCommision:  account.TakerCommission,
+		BuyerCommision:  account.BuyerCommission,
+		SellerCommision: account.SellerCommission,
+		CanTrade:        account.CanTrade,
+		CanWithdraw:     account.CanWithdraw,
+		CanDeposit:      account.CanDeposit,
+	}
+	for _, balance := range account.Balances {
+		b, err := ConvertBalance(balance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s balance: %w", balance.Asset, err)
+		}
+		a.Balances = append(a.Balances, b)
+	}
+	return a, nil
+}
+
+func ConvertBalance(balance externalClient.Balance) (*binance.Balance, error) {
+	free, err := parseDecimal("free", balance.Free)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := parseDecimal("locked", balance.Locked)
+	if err != nil {
+		return nil, err
+	}
+	b := &binance.Balance{
+		Asset:  balance.Asset,
+		Free:   free,
+		Locked: locked,
+	}
+	return b, nil
+}
+
+func ConvertTrade(trade *externalClient.TradeV3) (*binance.Trade, error) {
+	price, err := parseDecimal("price", trade.Price)
+	if err != nil {
+		return nil, err
+	}
+	qty, err := parseDecimal("qty", trade.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	commission, err := parseDecimal("commission", trade.Commission)
+	if err != nil {
+		return nil, err
+	}
+	t := &binance.Trade{
+		ID:              trade.ID,
+		Price:           price,
+		Qty:             qty,
+		Commission:      commission,
+		CommissionAsset: trade.CommissionAsset,
+		Time:            time.UnixMilli(trade.Time),
+		IsBuyer:         trade.IsBuyer,
+		IsMaker:         trade.IsMaker,
+		IsBestMatch:     trade.IsBestMatch,
+	}
+	return t, nil
+}
+
+func ConvertAggTrade(trade *externalClient.AggTrade) (*binance.AggTrade, error) {
+	price, err := parseDecimal("price", trade.Price)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := parseDecimal("quantity", trade.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	t := &binance.AggTrade{
+		ID:             int(trade.AggTradeID),
+		Price:          price,
+		Quantity:       quantity,
+		FirstTradeID:   int(trade.FirstTradeID),
+		LastTradeID:    int(trade.LastTradeID),
+		Timestamp:      time.UnixMilli(trade.Timestamp),
+		BuyerMaker:     trade.IsBuyerMaker,
+		BestPriceMatch: trade.IsBestPriceMatch,
+	}
+	return t, nil
+}
+
+func ConvertWSAggTradeEvent(event *externalClient.WsAggTradeEvent) (*binance.AggTradeEvent, error) {
+	trade, err := ConvertAggTrade(&externalClient.AggTrade{
+		AggTradeID:   event.AggTradeID,
+		Price:        event.Price,
+		Quantity:     event.Quantity,
+		FirstTradeID: event.FirstBreakdownTradeID,
+		LastTradeID:  event.LastBreakdownTradeID,
+		Timestamp:    event.TradeTime,
+		IsBuyerMaker: event.IsBuyerMaker,
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggTradeEvent := &binance.AggTradeEvent{
+		WSEvent: binance.WSEvent{
+			Type:   event.Event,
+			Time:   time.UnixMilli(event.Time),
+			Symbol: event.Symbol,
+		},
+		AggTrade: *trade,
+	}
+	return aggTradeEvent, nil
+}
+
+func ConvertPriceChangeStats(stats *externalClient.PriceChangeStats) (*binance.SymbolTicker24, error) {
+	priceChange, err := parseDecimal("priceChange", stats.PriceChange)
+	if err != nil {
+		return nil, err
+	}
+	priceChangePercent, err := parseDecimal("priceChangePercent", stats.PriceChangePercent)
+	if err != nil {
+		return nil, err
+	}
+	weightedAvgPrice, err := parseDecimal("weightedAvgPrice", stats.WeightedAvgPrice)
+	if err != nil {
+		return nil, err
+	}
+	prevClosePrice, err := parseDecimal("prevClosePrice", stats.PrevClosePrice)
+	if err != nil {
+		return nil, err
+	}
+	lastPrice, err := parseDecimal("lastPrice", stats.LastPrice)
+	if err != nil {
+		return nil, err
+	}
+	bidPrice, err := parseDecimal("bidPrice", stats.BidPrice)
+	if err != nil {
+		return nil, err
+	}
+	askPrice, err := parseDecimal("askPrice", stats.AskPrice)
+	if err != nil {
+		return nil, err
+	}
+	openPrice, err := parseDecimal("openPrice", stats.OpenPrice)
+	if err != nil {
+		return nil, err
+	}
+	highPrice, err := parseDecimal("highPrice", stats.HighPrice)
+	if err != nil {
+		return nil, err
+	}
+	lowPrice, err := parseDecimal("lowPrice", stats.LowPrice)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := parseDecimal("volume", stats.Volume)
+	if err != nil {
+		return nil, err
+	}
+	quoteVolume, err := parseDecimal("quoteVolume", stats.QuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+	ticker := &binance.SymbolTicker24{
+		Symbol: stats.Symbol,
+		Ticker24: binance.Ticker24{
+			PriceChange:        priceChange,
+			PriceChangePercent: priceChangePercent,
+			WeightedAvgPrice:   weightedAvgPrice,
+			PrevClosePrice:     prevClosePrice,
+			LastPrice:          lastPrice,
+			BidPrice:           bidPrice,
+			AskPrice:           askPrice,
+			OpenPrice:          openPrice,
+			HighPrice:          highPrice,
+			LowPrice:           lowPrice,
+			Volume:             volume,
+			QuoteVolume:        quoteVolume,
+			OpenTime:           time.UnixMilli(stats.OpenTime),
+			CloseTime:          time.UnixMilli(stats.CloseTime),
+			FirstID:            int(stats.FirstID),
+			LastID:             int(stats.LastID),
+			Count:              int(stats.Count),
+		},
+	}
+	return ticker, nil
+}
+
+func ConvertSymbolTicker(symbolTicker *externalClient.SymbolTicker) (*binance.SymbolTicker24, error) {
+	priceChange, err := parseDecimal("priceChange", symbolTicker.PriceChange)
+	if err != nil {
+		return nil, err
+	}
+	priceChangePercent, err := parseDecimal("priceChangePercent", symbolTicker.PriceChangePercent)
+	if err != nil {
+		return nil, err
+	}
+	weightedAvgPrice, err := parseDecimal("weightedAvgPrice", symbolTicker.WeightedAvgPrice)
+	if err != nil {
+		return nil, err
+	}
+	lastPrice, err := parseDecimal("lastPrice", symbolTicker.LastPrice)
+	if err != nil {
+		return nil, err
+	}
+	openPrice, err := parseDecimal("openPrice", symbolTicker.OpenPrice)
+	if err != nil {
+		return nil, err
+	}
+	highPrice, err := parseDecimal("highPrice", symbolTicker.HighPrice)
+	if err != nil {
+		return nil, err
+	}
+	lowPrice, err := parseDecimal("lowPrice", symbolTicker.LowPrice)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := parseDecimal("volume", symbolTicker.Volume)
+	if err != nil {
+		return nil, err
+	}
+	quoteVolume, err := parseDecimal("quoteVolume", symbolTicker.QuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+	ticker := &binance.SymbolTicker24{
+		Symbol: symbolTicker.Symbol,
+		Ticker24: binance.Ticker24{
+			PriceChange:        priceChange,
+			PriceChangePercent: priceChangePercent,
+			WeightedAvgPrice:   weightedAvgPrice,
+			LastPrice:          lastPrice,
+			OpenPrice:          openPrice,
+			HighPrice:          highPrice,
+			LowPrice:           lowPrice,
+			Volume:             volume,
+			QuoteVolume:        quoteVolume,
+			OpenTime:           time.UnixMilli(symbolTicker.OpenTime),
+			CloseTime:          time.UnixMilli(symbolTicker.CloseTime),
+			FirstID:            int(symbolTicker.FirstId),
+			LastID:             int(symbolTicker.LastId),
+			Count:              int(symbolTicker.Count),
+		},
+	}
+	return ticker, nil
+}
+
+func ConvertSymbolPrice(price *externalClient.SymbolPrice) (*binance.PriceTicker, error) {
+	p, err := parseDecimal("price", price.Price)
+	if err != nil {
+		return nil, err
+	}
+	return &binance.PriceTicker{
+		Symbol: price.Symbol,
+		Price:  p,
+	}, nil
+}
+
+func ConvertBookTicker(bookTicker *externalClient.BookTicker) (*binance.BookTicker, error) {
+	bidPrice, err := parseDecimal("bidPrice", bookTicker.BidPrice)
+	if err != nil {
+		return nil, err
+	}
+	bidQty, err := parseDecimal("bidQty", bookTicker.BidQuantity)
+	if err != nil {
+		return nil, err
+	}
+	askPrice, err := parseDecimal("askPrice", bookTicker.AskPrice)
+	if err != nil {
+		return nil, err
+	}
+	askQty, err := parseDecimal("askQty", bookTicker.AskQuantity)
+	if err != nil {
+		return nil, err
+	}
+	return &binance.BookTicker{
+		Symbol:   bookTicker.Symbol,
+		BidPrice: bidPrice,
+		BidQty:   bidQty,
+		AskPrice: askPrice,
+		AskQty:   askQty,
+	}, nil
+}
+
+func ConvertMarketTrade(trade *externalClient.Trade) (*binance.MarketTrade, error) {
+	price, err := parseDecimal("price", trade.Price)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := parseDecimal("qty", trade.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	quoteQuantity, err := parseDecimal("quoteQty", trade.QuoteQuantity)
+	if err != nil {
+		return nil, err
+	}
+	marketTrade := &binance.MarketTrade{
+		ID:             trade.ID,
+		Price:          price,
+		Quantity:       quantity,
+		QuoteQuantity:  quoteQuantity,
+		Timestamp:      time.UnixMilli(trade.Time),
+		BuyerMaker:     trade.IsBuyerMaker,
+		BestPriceMatch: trade.IsBestMatch,
+	}
+	return marketTrade, nil
+}
+
+func ConvertMarketTrades(trades []*externalClient.Trade) ([]*binance.MarketTrade, error) {
+	var marketTrades []*binance.MarketTrade
+	for _, trade := range trades {
+		marketTrade, err := ConvertMarketTrade(trade)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert trade: %w", err)
+		}
+		marketTrades = append(marketTrades, marketTrade)
+	}
+	return marketTrades, nil
+}
+
+func ConvertWSTradeEvent(event *externalClient.WsTradeEvent) (*binance.MarketTradeEvent, error) {
+	price, err := parseDecimal("price", event.Price)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := parseDecimal("qty", event.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	tradeEvent := &binance.MarketTradeEvent{
+		WSEvent: binance.WSEvent{
+			Type:   event.Event,
+			Time:   time.UnixMilli(event.Time),
+			Symbol: event.Symbol,
+		},
+		BuyerOrderID:  event.BuyerOrderID,
+		SellerOrderID: event.SellerOrderID,
+		MarketTrade: binance.MarketTrade{
+			ID:         event.TradeID,
+			Price:      price,
+			Quantity:   quantity,
+			Timestamp:  time.UnixMilli(event.TradeTime),
+			BuyerMaker: event.IsBuyerMaker,
+		},
+	}
+	return tradeEvent, nil
+}
+
+func ConvertWSMarketStatEvent(event *externalClient.WsMarketStatEvent) (*binance.Ticker24Event, error) {
+	priceChange, err := parseDecimal("priceChange", event.PriceChange)
+	if err != nil {
+		return nil, err
+	}
+	priceChangePercent, err := parseDecimal("priceChangePercent", event.PriceChangePercent)
+	if err != nil {
+		return nil, err
+	}
+	weightedAvgPrice, err := parseDecimal("weightedAvgPrice", event.WeightedAvgPrice)
+	if err != nil {
+		return nil, err
+	}
+	prevClosePrice, err := parseDecimal("prevClosePrice", event.PrevClosePrice)
+	if err != nil {
+		return nil, err
+	}
+	lastPrice, err := parseDecimal("lastPrice", event.LastPrice)
+	if err != nil {
+		return nil, err
+	}
+	bidPrice, err := parseDecimal("bidPrice", event.BidPrice)
+	if err != nil {
+		return nil, err
+	}
+	askPrice, err := parseDecimal("askPrice", event.AskPrice)
+	if err != nil {
+		return nil, err
+	}
+	openPrice, err := parseDecimal("openPrice", event.OpenPrice)
+	if err != nil {
+		return nil, err
+	}
+	highPrice, err := parseDecimal("highPrice", event.HighPrice)
+	if err != nil {
+		return nil, err
+	}
+	lowPrice, err := parseDecimal("lowPrice", event.LowPrice)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := parseDecimal("volume", event.BaseVolume)
+	if err != nil {
+		return nil, err
+	}
+	quoteVolume, err := parseDecimal("quoteVolume", event.QuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+	tickerEvent := &binance.Ticker24Event{
+		WSEvent: binance.WSEvent{
+			Type:   event.Event,
+			Time:   time.UnixMilli(event.Time),
+			Symbol: event.Symbol,
+		},
+		Ticker24: binance.Ticker24{
+			PriceChange:        priceChange,
+			PriceChangePercent: priceChangePercent,
+			WeightedAvgPrice:   weightedAvgPrice,
+			PrevClosePrice:     prevClosePrice,
+			LastPrice:          lastPrice,
+			BidPrice:           bidPrice,
+			AskPrice:           askPrice,
+			OpenPrice:          openPrice,
+			HighPrice:          highPrice,
+			LowPrice:           lowPrice,
+			Volume:             volume,
+			QuoteVolume:        quoteVolume,
+			OpenTime:           time.UnixMilli(event.OpenTime),
+			CloseTime:          time.UnixMilli(event.CloseTime),
+			FirstID:            int(event.FirstID),
+			LastID:             int(event.LastID),
+			Count:              int(event.Count),
+		},
+	}
+	return tickerEvent, nil
+}
+
+func ConvertWSMiniMarketStatEvent(event *externalClient.WsMiniMarketsStatEvent) (*binance.MiniTickerEvent, error) {
+	lastPrice, err := parseDecimal("lastPrice", event.LastPrice)
+	if err != nil {
+		return nil, err
+	}
+	openPrice, err := parseDecimal("openPrice", event.OpenPrice)
+	if err != nil {
+		return nil, err
+	}
+	highPrice, err := parseDecimal("highPrice", event.HighPrice)
+	if err != nil {
+		return nil, err
+	}
+	lowPrice, err := parseDecimal("lowPrice", event.LowPrice)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := parseDecimal("volume", event.BaseVolume)
+	if err != nil {
+		return nil, err
+	}
+	quoteVolume, err := parseDecimal("quoteVolume", event.QuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+	miniTickerEvent := &binance.MiniTickerEvent{
+		WSEvent: binance.WSEvent{
+			Type:   event.Event,
+			Time:   time.UnixMilli(event.Time),
+			Symbol: event.Symbol,
+		},
+		Ticker24: binance.Ticker24{
+			LastPrice:   lastPrice,
+			OpenPrice:   openPrice,
+			HighPrice:   highPrice,
+			LowPrice:    lowPrice,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+		},
+	}
+	return miniTickerEvent, nil
+}
+
+func ConvertWSBookTickerEvent(event *externalClient.WsBookTickerEvent) (*binance.BookTickerEvent, error) {
+	bidPrice, err := parseDecimal("bidPrice", event.BestBidPrice)
+	if err != nil {
+		return nil, err
+	}
+	bidQty, err := parseDecimal("bidQty", event.BestBidQty)
+	if err != nil {
+		return nil, err
+	}
+	askPrice, err := parseDecimal("askPrice", event.BestAskPrice)
+	if err != nil {
+		return nil, err
+	}
+	askQty, err := parseDecimal("askQty", event.BestAskQty)
+	if err != nil {
+		return nil, err
+	}
+	bookTickerEvent := &binance.BookTickerEvent{
+		WSEvent: binance.WSEvent{
+			Type:   "bookTicker",
+			Symbol: event.Symbol,
+		},
+		UpdateID: event.UpdateID,
+		BookTicker: binance.BookTicker{
+			Symbol:   event.Symbol,
+			BidPrice: bidPrice,
+			BidQty:   bidQty,
+			AskPrice: askPrice,
+			AskQty:   askQty,
+		},
+	}
+	return bookTickerEvent, nil
+}
+
+func ConvertDeposit(deposit *externalClient.Deposit) (*binance.Deposit, error) {
+	amount, err := parseDecimal("amount", deposit.Amount)
+	if err != nil {
+		return nil, err
+	}
+	d := &binance.Deposit{
+		InsertTime: time.UnixMilli(deposit.InsertTime),
+		Amount:     amount,
+		Asset:      deposit.Coin,
+		Status:     deposit.Status,
+	}
+	return d, nil
+}
+
+// withdrawApplyTimeLayout is the layout of applyTime field in withdraw
+// history, always in UTC.
+const withdrawApplyTimeLayout = "2006-01-02 15:04:05"
+
+func ConvertWithdrawal(withdraw *externalClient.Withdraw) (*binance.Withdrawal, error) {
+	amount, err := parseDecimal("amount", withdraw.Amount)
+	if err != nil {
+		return nil, err
+	}
+	var applyTime time.Time
+	if withdraw.ApplyTime != "" {
+		applyTime, err = time.ParseInLocation(withdrawApplyTimeLayout, withdraw.ApplyTime, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse applyTime %q: %w", withdraw.ApplyTime, err)
+		}
+	}
+	w := &binance.Withdrawal{
+		Amount:    amount,
+		Address:   withdraw.Address,
+		TxID:      withdraw.TxID,
+		Asset:     withdraw.Coin,
+		ApplyTime: applyTime,
+		Status:    withdraw.Status,
+	}
+	return w, nil
+}
+
+func ConvertWSUserDataEvent(event *externalClient.WsUserDataEvent) (*binance.UserDataEvent, error) {
+	userDataEvent := &binance.UserDataEvent{
+		WSEvent: binance.WSEvent{
+			Type: string(event.Event),
+			Time: time.UnixMilli(event.Time),
+		},
+	}
+	var err error
+	switch event.Event {
+	case externalClient.UserDataEventTypeOutboundAccountPosition:
+		userDataEvent.AccountPosition, err = ConvertWSAccountPosition(&event.AccountUpdate)
+	case externalClient.UserDataEventTypeBalanceUpdate:
+		userDataEvent.BalanceUpdate, err = ConvertWSBalanceUpdate(&event.BalanceUpdate)
+	case externalClient.UserDataEventTypeExecutionReport:
+		userDataEvent.Symbol = event.OrderUpdate.Symbol
+		userDataEvent.ExecutionReport, err = ConvertWSExecutionReport(&event.OrderUpdate)
+	default:
+		return nil, fmt.Errorf("unsupported user data event type %q", event.Event)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return userDataEvent, nil
+}
+
+func ConvertWSAccountPosition(update *externalClient.WsAccountUpdateList) (*binance.AccountPosition, error) {
+	position := &binance.AccountPosition{
+		LastUpdateTime: time.UnixMilli(update.AccountUpdateTime),
+	}
+	for _, accountUpdate := range update.WsAccountUpdates {
+		balance, err := ConvertBalance(externalClient.Balance{
+			Asset:  accountUpdate.Asset,
+			Free:   accountUpdate.Free,
+			Locked: accountUpdate.Locked,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s balance: %w", accountUpdate.Asset, err)
+		}
+		position.Balances = append(position.Balances, balance)
+	}
+	return position, nil
+}
+
+func ConvertWSBalanceUpdate(update *externalClient.WsBalanceUpdate) (*binance.BalanceUpdate, error) {
+	delta, err := parseDecimal("balance delta", update.Change)
+	if err != nil {
+		return nil, err
+	}
+	balanceUpdate := &binance.BalanceUpdate{
+		Asset:     update.Asset,
+		Delta:     delta,
+		ClearTime: time.UnixMilli(update.TransactionTime),
+	}
+	return balanceUpdate, nil
+}
+
+func ConvertWSExecutionReport(update *externalClient.WsOrderUpdate) (*binance.ExecutionReport, error) {
+	quantity, err := parseDecimal("quantity", update.Volume)
+	if err != nil {
+		return nil, err
+	}
+	price, err := parseDecimal("price", update.Price)
+	if err != nil {
+		return nil, err
+	}
+	stopPrice, err := parseDecimal("stopPrice", update.StopPrice)
+	if err != nil {
+		return nil, err
+	}
+	icebergQty, err := parseDecimal("icebergQty", update.IceBergVolume)
+	if err != nil {
+		return nil, err
+	}
+	lastFilledQty, err := parseDecimal("lastFilledQty", update.LatestVolume)
+	if err != nil {
+		return nil, err
+	}
+	lastFilledPrice, err := parseDecimal("lastFilledPrice", update.LatestPrice)
+	if err != nil {
+		return nil, err
+	}
+	lastQuoteQty, err := parseDecimal("lastQuoteQty", update.LatestQuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+	cumulativeFilledQty, err := parseDecimal("cumulativeFilledQty", update.FilledVolume)
+	if err != nil {
+		return nil, err
+	}
+	cumulativeQuoteQty, err := parseDecimal("cumulativeQuoteQty", update.FilledQuoteVolume)
+	if err != nil {
+		return nil, err
+	}
+	commission, err := parseDecimal("commission", update.FeeCost)
+	if err != nil {
+		return nil, err
+	}
+	report := &binance.ExecutionReport{
+		Symbol:              update.Symbol,
+		OrderID:             update.Id,
+		ClientOrderID:       update.ClientOrderId,
+		OrigClientOrderID:   update.OrigCustomOrderId,
+		Side:                binance.OrderSide(update.Side),
+		Type:                binance.OrderType(update.Type),
+		TimeInForce:         binance.TimeInForce(update.TimeInForce),
+		Quantity:            quantity,
+		Price:               price,
+		StopPrice:           stopPrice,
+		IcebergQty:          icebergQty,
+		ExecutionType:       binance.ExecutionType(update.ExecutionType),
+		Status:              binance.OrderStatus(update.Status),
+		RejectReason:        update.RejectReason,
+		LastFilledQty:       lastFilledQty,
+		LastFilledPrice:     lastFilledPrice,
+		LastQuoteQty:        lastQuoteQty,
+		CumulativeFilledQty: cumulativeFilledQty,
+		CumulativeQuoteQty:  cumulativeQuoteQty,
+		Commission:          commission,
+		CommissionAsset:     update.FeeAsset,
+		TradeID:             update.TradeId,
+		IsMaker:             update.IsMaker,
+		IsWorking:           update.IsInOrderBook,
+		TransactionTime:     time.UnixMilli(update.TransactionTime),
+		CreationTime:        time.UnixMilli(update.CreateTime),
+	}
+	return report, nil
+}
+
+func ConvertExchangeInfo(exchangeInfo *externalClient.ExchangeInfo) (*binance.ExchangeInfo, error) {
+	ei := &binance.ExchangeInfo{
+		Timezone:   exchangeInfo.Timezone,
+		ServerTime: time.UnixMilli(exchangeInfo.ServerTime),
+	}
+	for _, rateLimit := range exchangeInfo.RateLimits {
+		ei.RateLimits = append(ei.RateLimits, &binance.RateLimit{
+			Type:        binance.RateLimitType(rateLimit.RateLimitType),
+			Interval:    binance.RateLimitInterval(rateLimit.Interval),
+			IntervalNum: int(rateLimit.IntervalNum),
+			Limit:       int(rateLimit.Limit),
+		})
+	}
+	for i := range exchangeInfo.Symbols {
+		symbolInfo, err := ConvertSymbolInfo(&exchangeInfo.Symbols[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert symbol %s: %w", exchangeInfo.Symbols[i].Symbol, err)
+		}
+		ei.Symbols = append(ei.Symbols, symbolInfo)
+	}
+	return ei, nil
+}
+
+func ConvertSymbolInfo(symbol *externalClient.Symbol) (*binance.SymbolInfo, error) {
+	filters, err := ConvertSymbolFilters(symbol.Filters)
+	if err != nil {
+		return nil, err
+	}
+	si := &binance.SymbolInfo{
+		Symbol:                     symbol.Symbol,
+		Status:                     binance.SymbolStatus(symbol.Status),
+		BaseAsset:                  symbol.BaseAsset,
+		BaseAssetPrecision:         symbol.BaseAssetPrecision,
+		QuoteAsset:                 symbol.QuoteAsset,
+		QuotePrecision:             symbol.QuotePrecision,
+		IcebergAllowed:             symbol.IcebergAllowed,
+		OCOAllowed:                 symbol.OcoAllowed,
+		QuoteOrderQtyMarketAllowed: symbol.QuoteOrderQtyMarketAllowed,
+		IsSpotTradingAllowed:       symbol.IsSpotTradingAllowed,
+		Filters:                    *filters,
+	}
+	for _, orderType := range symbol.OrderTypes {
+		si.OrderTypes = append(si.OrderTypes, binance.OrderType(orderType))
+	}
+	return si, nil
+}
+
+func ConvertSymbolFilters(filters []map[string]interface{}) (*binance.SymbolFilters, error) {
+	sf := &binance.SymbolFilters{}
+	for _, filter := range filters {
+		filterType, _ := filter["filterType"].(string)
+		var err error
+		switch filterType {
+		case "PRICE_FILTER":
+			sf.Price, err = convertPriceFilter(filter)
+		case "LOT_SIZE":
+			sf.LotSize, err = convertLotSizeFilter(filter)
+		case "MARKET_LOT_SIZE":
+			sf.MarketLotSize, err = convertLotSizeFilter(filter)
+		case "MIN_NOTIONAL":
+			sf.MinNotional, err = convertMinNotionalFilter(filter)
+		case "NOTIONAL":
+			sf.Notional, err = convertNotionalFilter(filter)
+		case "PERCENT_PRICE":
+			sf.PercentPrice, err = convertPercentPriceFilter(filter)
+		case "MAX_NUM_ORDERS":
+			sf.MaxNumOrders = &binance.MaxNumOrdersFilter{
+				MaxNumOrders: filterInt(filter, "maxNumOrders"),
+			}
+		case "TRAILING_DELTA":
+			sf.TrailingDelta = &binance.TrailingDeltaFilter{
+				MinTrailingAboveDelta: filterInt(filter, "minTrailingAboveDelta"),
+				MaxTrailingAboveDelta: filterInt(filter, "maxTrailingAboveDelta"),
+				MinTrailingBelowDelta: filterInt(filter, "minTrailingBelowDelta"),
+				MaxTrailingBelowDelta: filterInt(filter, "maxTrailingBelowDelta"),
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s: %w", filterType, err)
+		}
+	}
+	return sf, nil
+}
+
+func convertPriceFilter(filter map[string]interface{}) (*binance.PriceFilter, error) {
+	minPrice, err := filterDecimal(filter, "minPrice")
+	if err != nil {
+		return nil, err
+	}
+	maxPrice, err := filterDecimal(filter, "maxPrice")
+	if err != nil {
+		return nil, err
+	}
+	tickSize, err := filterDecimal(filter, "tickSize")
+	if err != nil {
+		return nil, err
+	}
+	return &binance.PriceFilter{MinPrice: minPrice, MaxPrice: maxPrice, TickSize: tickSize}, nil
+}
+
+func convertLotSizeFilter(filter map[string]interface{}) (*binance.LotSizeFilter, error) {
+	minQty, err := filterDecimal(filter, "minQty")
+	if err != nil {
+		return nil, err
+	}
+	maxQty, err := filterDecimal(filter, "maxQty")
+	if err != nil {
+		return nil, err
+	}
+	stepSize, err := filterDecimal(filter, "stepSize")
+	if err != nil {
+		return nil, err
+	}
+	return &binance.LotSizeFilter{MinQty: minQty, MaxQty: maxQty, StepSize: stepSize}, nil
+}
+
+func convertMinNotionalFilter(filter map[string]interface{}) (*binance.MinNotionalFilter, error) {
+	minNotional, err := filterDecimal(filter, "minNotional")
+	if err != nil {
+		return nil, err
+	}
+	f := &binance.MinNotionalFilter{
+		MinNotional:   minNotional,
+		ApplyToMarket: filterBool(filter, "applyToMarket"),
+		AvgPriceMins:  filterInt(filter, "avgPriceMins"),
+	}
+	return f, nil
+}
+
+func convertNotionalFilter(filter map[string]interface{}) (*binance.NotionalFilter, error) {
+	minNotional, err := filterDecimal(filter, "minNotional")
+	if err != nil {
+		return nil, err
+	}
+	maxNotional, err := filterDecimal(filter, "maxNotional")
+	if err != nil {
+		return nil, err
+	}
+	f := &binance.NotionalFilter{
+		MinNotional:      minNotional,
+		ApplyMinToMarket: filterBool(filter, "applyMinToMarket"),
+		MaxNotional:      maxNotional,
+		ApplyMaxToMarket: filterBool(filter, "applyMaxToMarket"),
+		AvgPriceMins:     filterInt(filter, "avgPriceMins"),
+	}
+	return f, nil
+}
+
+func convertPercentPriceFilter(filter map[string]interface{}) (*binance.PercentPriceFilter, error) {
+	multiplierUp, err := filterDecimal(filter, "multiplierUp")
+	if err != nil {
+		return nil, err
+	}
+	multiplierDown, err := filterDecimal(filter, "multiplierDown")
+	if err != nil {
+		return nil, err
+	}
+	f := &binance.PercentPriceFilter{
+		MultiplierUp:   multiplierUp,
+		MultiplierDown: multiplierDown,
+		AvgPriceMins:   filterInt(filter, "avgPriceMins"),
+	}
+	return f, nil
+}
+
+func filterDecimal(filter map[string]interface{}, key string) (binance.Decimal, error) {
+	value, _ := filter[key].(string)
+	return parseDecimal(key, value)
+}
+
+func filterInt(filter map[string]interface{}, key string) int {
+	switch value := filter[key].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	case int64:
+		return int(value)
+	}
+	return 0
+}
+
+func filterBool(filter map[string]interface{}, key string) bool {
+	value, _ := filter[key].(bool)
+	return value
+}
+
+func parseDecimal(name, value string) (binance.Decimal, error) {
+	if value == "" {
+		return binance.Decimal{}, nil
+	}
+	d, err := binance.ParseDecimal(value)
+	if err != nil {
+		return binance.Decimal{}, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,335 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asnowflake777/go-binance"
+)
+
+// RateLimitPolicy defines what RateLimiter does when request doesn't fit
+// into limits.
+type RateLimitPolicy int
+
+const (
+	// RateLimitBlock waits until request fits into limits or context is
+	// done.
+	RateLimitBlock RateLimitPolicy = iota
+	// RateLimitFailFast returns RateLimitError immediately.
+	RateLimitFailFast
+)
+
+// DefaultRateLimits are used until limits from exchange info are loaded.
+var DefaultRateLimits = []*binance.RateLimit{
+	{Type: binance.RateLimitTypeRequestWeight, Interval: binance.RateLimitIntervalMinute, IntervalNum: 1, Limit: 6000},
+	{Type: binance.RateLimitTypeOrders, Interval: binance.RateLimitIntervalSecond, IntervalNum: 10, Limit: 100},
+	{Type: binance.RateLimitTypeOrders, Interval: binance.RateLimitIntervalDay, IntervalNum: 1, Limit: 200000},
+	{Type: binance.RateLimitTypeRawRequests, Interval: binance.RateLimitIntervalMinute, IntervalNum: 5, Limit: 61000},
+}
+
+// RateLimitError is returned when request exceeds rate limit under
+// RateLimitFailFast policy or IP is banned by the exchange.
+type RateLimitError struct {
+	Type       binance.RateLimitType
+	RetryAfter time.Duration
+}
+
+// Error returns formatted error message.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded, retry after %s", e.Type, e.RetryAfter)
+}
+
+// Retryable reports that request may be repeated once RetryAfter passes.
+func (e *RateLimitError) Retryable() bool {
+	return true
+}
+
+// RetryDelay returns time to wait before repeating request.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// Is makes RateLimitError match binance.ErrTooManyRequests.
+func (e *RateLimitError) Is(target error) bool {
+	return target == binance.ErrTooManyRequests
+}
+
+// RateLimitUsage represents current usage of single rate limit.
+type RateLimitUsage struct {
+	Limit   binance.RateLimit
+	Used    int
+	ResetAt time.Time
+}
+
+// RateLimiter tracks REQUEST_WEIGHT, ORDERS and RAW_REQUESTS limits in fixed
+// windows aligned the same way as on the exchange. Usage is resynced from
+// X-MBX-USED-WEIGHT-* and X-MBX-ORDER-COUNT-* response headers, and
+// Retry-After of 429 and 418 responses pauses all requests.
+type RateLimiter struct {
+	policy RateLimitPolicy
+
+	mu          sync.Mutex
+	windows     []*rateWindow
+	bannedUntil time.Time
+}
+
+type rateWindow struct {
+	limit binance.RateLimit
+	start time.Time
+	used  int
+}
+
+// NewRateLimiter returns limiter using DefaultRateLimits.
+func NewRateLimiter(policy RateLimitPolicy) *RateLimiter {
+	l := &RateLimiter{policy: policy}
+	l.SetLimits(DefaultRateLimits)
+	return l
+}
+
+// SetLimits replaces tracked limits, keeping usage of limits which are
+// still present.
+func (l *RateLimiter) SetLimits(limits []*binance.RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	windows := make([]*rateWindow, 0, len(limits))
+	for _, limit := range limits {
+		w := &rateWindow{limit: *limit}
+		for _, old := range l.windows {
+			if old.limit.Type == limit.Type && old.limit.Duration() == limit.Duration() {
+				w.start, w.used = old.start, old.used
+			}
+		}
+		windows = append(windows, w)
+	}
+	l.windows = windows
+}
+
+// Wait reserves weight of request, which places given number of orders.
+// Depending on policy it blocks until reservation fits into limits or
+// returns RateLimitError.
+func (l *RateLimiter) Wait(ctx context.Context, weight, orders int) error {
+	for {
+		delay, limitType := l.reserve(time.Now(), weight, orders)
+		if delay <= 0 {
+			return nil
+		}
+		if l.policy == RateLimitFailFast {
+			return &RateLimitError{Type: limitType, RetryAfter: delay}
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Usage returns current usage of all tracked limits.
+func (l *RateLimiter) Usage() []RateLimitUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	usage := make([]RateLimitUsage, 0, len(l.windows))
+	for _, w := range l.windows {
+		w.roll(now)
+		usage = append(usage, RateLimitUsage{
+			Limit:   w.limit,
+			Used:    w.used,
+			ResetAt: w.start.Add(w.limit.Duration()),
+		})
+	}
+	return usage
+}
+
+// Update resyncs usage from response status and headers.
+func (l *RateLimiter) Update(statusCode int, header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusTeapot {
+		retryAfter := time.Minute
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		if until := now.Add(retryAfter); until.After(l.bannedUntil) {
+			l.bannedUntil = until
+		}
+	}
+	for key, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		limitType, interval, ok := parseUsageHeader(key)
+		if !ok {
+			continue
+		}
+		used, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+		for _, w := range l.windows {
+			if w.limit.Type != limitType || w.limit.Duration() != interval {
+				continue
+			}
+			w.roll(now)
+			// Server value includes requests of other clients sharing IP,
+			// local value includes requests still in flight.
+			if used > w.used {
+				w.used = used
+			}
+		}
+	}
+}
+
+func (l *RateLimiter) reserve(now time.Time, weight, orders int) (time.Duration, binance.RateLimitType) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Before(l.bannedUntil) {
+		return l.bannedUntil.Sub(now), binance.RateLimitTypeRequestWeight
+	}
+	for _, w := range l.windows {
+		w.roll(now)
+		cost := w.cost(weight, orders)
+		// Request heavier than the whole limit is let through on empty
+		// window, otherwise it would never fit.
+		if cost > 0 && w.used > 0 && w.used+cost > w.limit.Limit {
+			return w.start.Add(w.limit.Duration()).Sub(now), w.limit.Type
+		}
+	}
+	for _, w := range l.windows {
+		w.used += w.cost(weight, orders)
+	}
+	return 0, ""
+}
+
+func (w *rateWindow) roll(now time.Time) {
+	start := now.Truncate(w.limit.Duration())
+	if start.After(w.start) {
+		w.start = start
+		w.used = 0
+	}
+}
+
+func (w *rateWindow) cost(weight, orders int) int {
+	switch w.limit.Type {
+	case binance.RateLimitTypeRequestWeight:
+		return weight
+	case binance.RateLimitTypeOrders:
+		return orders
+	case binance.RateLimitTypeRawRequests:
+		return 1
+	}
+	return 0
+}
+
+// parseUsageHeader parses headers like X-MBX-USED-WEIGHT-1M and
+// X-MBX-ORDER-COUNT-10S.
+func parseUsageHeader(key string) (binance.RateLimitType, time.Duration, bool) {
+	key = strings.ToUpper(key)
+	var limitType binance.RateLimitType
+	var suffix string
+	switch {
+	case strings.HasPrefix(key, "X-MBX-USED-WEIGHT-"):
+		limitType, suffix = binance.RateLimitTypeRequestWeight, strings.TrimPrefix(key, "X-MBX-USED-WEIGHT-")
+	case strings.HasPrefix(key, "X-MBX-ORDER-COUNT-"):
+		limitType, suffix = binance.RateLimitTypeOrders, strings.TrimPrefix(key, "X-MBX-ORDER-COUNT-")
+	default:
+		return "", 0, false
+	}
+	if len(suffix) < 2 {
+		return "", 0, false
+	}
+	num, err := strconv.Atoi(suffix[:len(suffix)-1])
+	if err != nil {
+		return "", 0, false
+	}
+	var unit time.Duration
+	switch suffix[len(suffix)-1] {
+	case 'S':
+		unit = time.Second
+	case 'M':
+		unit = time.Minute
+	case 'H':
+		unit = time.Hour
+	case 'D':
+		unit = 24 * time.Hour
+	default:
+		return "", 0, false
+	}
+	return limitType, time.Duration(num) * unit, true
+}
+
+// Request weights of used endpoints.
+const (
+	weightPing               = 1
+	weightTime               = 1
+	weightExchangeInfo       = 20
+	weightAggTrades          = 2
+	weightRecentTrades       = 25
+	weightHistoricalTrades   = 25
+	weightKlines             = 2
+	weightNewOrder           = 1
+	weightQueryOrder         = 4
+	weightCancelOrder        = 1
+	weightOpenOrdersSymbol   = 6
+	weightOpenOrdersAll      = 80
+	weightAllOrders          = 20
+	weightAccount            = 20
+	weightMyTrades           = 20
+	weightUserDataStream     = 2
+	ordersPerNewOrderRequest = 1
+)
+
+// ticker24Weight returns weight of 24hr ticker request for given number of
+// symbols, zero meaning all symbols.
+func ticker24Weight(symbols int) int {
+	switch {
+	case symbols == 0:
+		return 80
+	case symbols <= 20:
+		return 2
+	case symbols <= 100:
+		return 40
+	default:
+		return 80
+	}
+}
+
+// rollingWindowTickerWeight returns weight of rolling window ticker request
+// for given number of symbols.
+func rollingWindowTickerWeight(symbols int) int {
+	if symbols <= 50 {
+		return 4 * symbols
+	}
+	return 200
+}
+
+// symbolTickerWeight returns weight of price or book ticker request for
+// given number of symbols, zero meaning all symbols.
+func symbolTickerWeight(symbols int) int {
+	if symbols == 1 {
+		return 2
+	}
+	return 4
+}
+
+// orderBookWeight returns weight of depth request with given limit.
+func orderBookWeight(limit int) int {
+	switch {
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 25
+	case limit <= 1000:
+		return 50
+	default:
+		return 250
+	}
+}
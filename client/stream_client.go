@@ -0,0 +1,489 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	extBinanceClient "github.com/adshao/go-binance/v2"
+	"github.com/asnowflake777/go-binance"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultStreamEndpoint is combined stream endpoint of the exchange.
+	DefaultStreamEndpoint = "wss://stream.binance.com:9443/stream"
+	// MaxStreamsPerConnection is maximum number of streams single
+	// connection may be subscribed to.
+	MaxStreamsPerConnection = 1024
+
+	// Exchange accepts at most 5 incoming messages per second.
+	streamRequestInterval = 250 * time.Millisecond
+	streamRequestTimeout  = 10 * time.Second
+	streamReconnectDelay  = 5 * time.Second
+	// Exchange pings every 20 seconds, connection silent for longer than
+	// streamReadTimeout is considered dead.
+	streamReadTimeout = time.Minute
+)
+
+var (
+	ErrStreamClientNotStarted = errors.New("stream client is not started")
+	ErrStreamClientStarted    = errors.New("stream client is already started")
+	ErrAlreadySubscribed      = errors.New("already subscribed to stream")
+	ErrTooManyStreams         = errors.New("too many streams")
+
+	// errDisconnected means request wasn't sent because connection is
+	// being reopened. Streams registered meanwhile are subscribed by
+	// resubscribe.
+	errDisconnected = errors.New("stream connection is being reopened")
+)
+
+// StreamClient multiplexes streams over single connection to combined
+// stream endpoint. Streams are subscribed and unsubscribed at runtime and
+// events of each are delivered to its own channel, buffered according to
+// WebsocketConfig. All streams share connection, so OverflowBlock on one
+// of them stalls the others.
+//
+// Connection dropped by the exchange is reopened and all streams are
+// subscribed again, events sent meanwhile are lost. Streams subscribed while
+// connection is being reopened are subscribed together with them.
+type StreamClient struct {
+	endpoint string
+	logger   *zap.Logger
+	config   WebsocketConfig
+	dropped  atomic.Uint64
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	subs    map[string]*streamSubscription
+	pending map[int64]chan error
+	nextID  int64
+	// connected is false while dropped connection is being reopened.
+	connected bool
+
+	writeMu   sync.Mutex
+	lastWrite time.Time
+}
+
+type streamSubscription struct {
+	handle func(data json.RawMessage)
+	doneC  chan struct{}
+	once   sync.Once
+}
+
+func (sub *streamSubscription) stop() {
+	sub.once.Do(func() { close(sub.doneC) })
+}
+
+type streamRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	ID     int64    `json:"id"`
+}
+
+type streamMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	ID     *int64          `json:"id"`
+	Error  *binance.Error  `json:"error"`
+}
+
+// NewStreamClient returns client of endpoint, empty endpoint means
+// DefaultStreamEndpoint.
+func NewStreamClient(endpoint string, logger *zap.Logger, config WebsocketConfig) *StreamClient {
+	if endpoint == "" {
+		endpoint = DefaultStreamEndpoint
+	}
+	return &StreamClient{
+		endpoint: endpoint,
+		logger:   logger,
+		config:   config,
+		subs:     make(map[string]*streamSubscription),
+		pending:  make(map[int64]chan error),
+	}
+}
+
+// Start opens connection and serves it until ctx is done. Returned channel
+// is closed once connection is closed and all subscriptions are stopped.
+// Client can be started only once.
+func (s *StreamClient) Start(ctx context.Context) (chan struct{}, error) {
+	s.mu.Lock()
+	started := s.conn != nil
+	s.mu.Unlock()
+	if started {
+		return nil, ErrStreamClientStarted
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.conn != nil {
+		s.mu.Unlock()
+		conn.Close()
+		return nil, ErrStreamClientStarted
+	}
+	s.conn = conn
+	s.connected = true
+	s.mu.Unlock()
+	doneC := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.conn.Close()
+	}()
+	go s.run(ctx, conn, doneC)
+	return doneC, nil
+}
+
+// DroppedEvents returns number of events dropped or coalesced by overflow
+// policy.
+func (s *StreamClient) DroppedEvents() uint64 {
+	return s.dropped.Load()
+}
+
+// Streams returns names of subscribed streams.
+func (s *StreamClient) Streams() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	streams := make([]string, 0, len(s.subs))
+	for stream := range s.subs {
+		streams = append(streams, stream)
+	}
+	return streams
+}
+
+// SubscribeKline subscribes to kline stream until ctx is done.
+func (s *StreamClient) SubscribeKline(ctx context.Context, kwr binance.KlineWebsocketRequest) (chan *binance.KlineEvent, chan struct{}, error) {
+	stream := fmt.Sprintf("%s@kline_%s", strings.ToLower(kwr.Symbol), kwr.Interval)
+	return subscribe(ctx, s, stream, klineEventKey, func(data json.RawMessage) (*binance.KlineEvent, error) {
+		event := new(extBinanceClient.WsKlineEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			return nil, err
+		}
+		return ConvertWSKlineEvent(event)
+	})
+}
+
+// SubscribeDepth subscribes to depth diff stream until ctx is done.
+func (s *StreamClient) SubscribeDepth(ctx context.Context, dwr binance.DepthWebsocketRequest) (chan *binance.DepthEvent, chan struct{}, error) {
+	stream := strings.ToLower(dwr.Symbol) + "@depth"
+	if dwr.UpdateSpeed > 0 && dwr.UpdateSpeed < time.Second {
+		stream += "@100ms"
+	}
+	return subscribe(ctx, s, stream, nil, func(data json.RawMessage) (*binance.DepthEvent, error) {
+		var message wsDepthMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			return nil, err
+		}
+		return ConvertWSDepthEvent(message.event())
+	})
+}
+
+// SubscribeTrade subscribes to aggregate trade stream until ctx is done.
+func (s *StreamClient) SubscribeTrade(ctx context.Context, twr binance.TradeWebsocketRequest) (chan *binance.AggTradeEvent, chan struct{}, error) {
+	stream := strings.ToLower(twr.Symbol) + "@aggTrade"
+	return subscribe(ctx, s, stream, nil, func(data json.RawMessage) (*binance.AggTradeEvent, error) {
+		event := new(extBinanceClient.WsAggTradeEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			return nil, err
+		}
+		return ConvertWSAggTradeEvent(event)
+	})
+}
+
+// SubscribeRawTrade subscribes to individual trade stream until ctx is done.
+func (s *StreamClient) SubscribeRawTrade(ctx context.Context, twr binance.TradeWebsocketRequest) (chan *binance.MarketTradeEvent, chan struct{}, error) {
+	stream := strings.ToLower(twr.Symbol) + "@trade"
+	return subscribe(ctx, s, stream, nil, func(data json.RawMessage) (*binance.MarketTradeEvent, error) {
+		event := new(extBinanceClient.WsTradeEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			return nil, err
+		}
+		return ConvertWSTradeEvent(event)
+	})
+}
+
+// SubscribeTicker subscribes to 24hr ticker stream until ctx is done.
+func (s *StreamClient) SubscribeTicker(ctx context.Context, tr binance.TickerRequest) (chan *binance.Ticker24Event, chan struct{}, error) {
+	stream := strings.ToLower(tr.Symbol) + "@ticker"
+	return subscribe(ctx, s, stream, ticker24EventKey, func(data json.RawMessage) (*binance.Ticker24Event, error) {
+		event := new(extBinanceClient.WsMarketStatEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			return nil, err
+		}
+		return ConvertWSMarketStatEvent(event)
+	})
+}
+
+// subscribe registers stream, sends SUBSCRIBE and delivers events decoded
+// by decode until ctx is done, when UNSUBSCRIBE is sent.
+func subscribe[T any](
+	ctx context.Context,
+	s *StreamClient,
+	stream string,
+	key func(T) string,
+	decode func(json.RawMessage) (T, error),
+) (chan T, chan struct{}, error) {
+	buffer := newEventBuffer(s.config, &s.dropped, key)
+	sub := &streamSubscription{
+		doneC: make(chan struct{}),
+		handle: func(data json.RawMessage) {
+			event, err := decode(data)
+			if err != nil {
+				s.logger.Error("failed to decode stream event", zap.String("stream", stream), zap.Error(err))
+				return
+			}
+			buffer.push(ctx, event)
+		},
+	}
+
+	s.mu.Lock()
+	switch {
+	case s.conn == nil:
+		s.mu.Unlock()
+		return nil, nil, ErrStreamClientNotStarted
+	case s.subs[stream] != nil:
+		s.mu.Unlock()
+		return nil, nil, fmt.Errorf("%w: %s", ErrAlreadySubscribed, stream)
+	case len(s.subs) >= MaxStreamsPerConnection:
+		s.mu.Unlock()
+		return nil, nil, ErrTooManyStreams
+	}
+	s.subs[stream] = sub
+	s.mu.Unlock()
+
+	if err := s.request(ctx, "SUBSCRIBE", []string{stream}); err != nil && !errors.Is(err, errDisconnected) {
+		s.unregister(stream, sub)
+		return nil, nil, err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.unregister(stream, sub)
+			unsubscribeCtx, cancel := context.WithTimeout(context.Background(), streamRequestTimeout)
+			defer cancel()
+			err := s.request(unsubscribeCtx, "UNSUBSCRIBE", []string{stream})
+			if err != nil && !errors.Is(err, errDisconnected) {
+				s.logger.Warn("failed to unsubscribe from stream", zap.String("stream", stream), zap.Error(err))
+			}
+		case <-sub.doneC:
+		}
+	}()
+	events, doneC := buffer.forward(ctx, sub.doneC)
+	return events, doneC, nil
+}
+
+func (s *StreamClient) unregister(stream string, sub *streamSubscription) {
+	s.mu.Lock()
+	if s.subs[stream] == sub {
+		delete(s.subs, stream)
+	}
+	s.mu.Unlock()
+	sub.stop()
+}
+
+// request sends request and waits for response to it. It returns
+// errDisconnected without sending while connection is being reopened.
+func (s *StreamClient) request(ctx context.Context, method string, streams []string) error {
+	s.mu.Lock()
+	if !s.connected {
+		s.mu.Unlock()
+		return errDisconnected
+	}
+	conn := s.conn
+	s.nextID++
+	id := s.nextID
+	responseC := make(chan error, 1)
+	s.pending[id] = responseC
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}()
+
+	if err := s.write(ctx, conn, streamRequest{Method: method, Params: streams, ID: id}); err != nil {
+		return err
+	}
+	timer := time.NewTimer(streamRequestTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-responseC:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return fmt.Errorf("%s request timed out", method)
+	}
+}
+
+// write sends request keeping to incoming message limit of the exchange.
+func (s *StreamClient) write(ctx context.Context, conn *websocket.Conn, request streamRequest) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if wait := time.Until(s.lastWrite.Add(streamRequestInterval)); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	s.lastWrite = time.Now()
+	return conn.WriteJSON(request)
+}
+
+func (s *StreamClient) run(ctx context.Context, conn *websocket.Conn, doneC chan struct{}) {
+	defer close(doneC)
+	defer s.stopAll()
+	for {
+		err := s.read(conn)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.Warn("stream connection closed", zap.Error(err))
+		s.mu.Lock()
+		s.connected = false
+		s.mu.Unlock()
+		conn = s.reconnect(ctx)
+		if conn == nil {
+			return
+		}
+		go s.resubscribe(ctx)
+	}
+}
+
+// read dispatches messages of conn until it fails. Read deadline is
+// extended by every message and ping, so that half-open connection fails
+// with timeout too.
+func (s *StreamClient) read(conn *websocket.Conn) error {
+	extendDeadline := func() {
+		conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+	}
+	conn.SetPingHandler(func(appData string) error {
+		extendDeadline()
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(streamRequestTimeout))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+	extendDeadline()
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		extendDeadline()
+		var message streamMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			s.logger.Error("failed to decode stream message", zap.Error(err))
+			continue
+		}
+		s.mu.Lock()
+		if message.ID != nil {
+			if responseC, ok := s.pending[*message.ID]; ok {
+				var err error
+				if message.Error != nil {
+					err = *message.Error
+				}
+				responseC <- err
+			}
+			s.mu.Unlock()
+			continue
+		}
+		sub := s.subs[message.Stream]
+		s.mu.Unlock()
+		if sub != nil {
+			sub.handle(message.Data)
+		}
+	}
+}
+
+// reconnect dials endpoint until it succeeds or ctx is done.
+func (s *StreamClient) reconnect(ctx context.Context) *websocket.Conn {
+	for {
+		timer := time.NewTimer(streamReconnectDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.endpoint, nil)
+		if err != nil {
+			s.logger.Error("failed to reconnect stream client", zap.Error(err))
+			continue
+		}
+		s.mu.Lock()
+		// Connection is closed on ctx done under the same lock.
+		if ctx.Err() != nil {
+			s.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		s.conn = conn
+		s.connected = true
+		s.mu.Unlock()
+		return conn
+	}
+}
+
+// resubscribe subscribes new connection to all registered streams.
+func (s *StreamClient) resubscribe(ctx context.Context) {
+	streams := s.Streams()
+	if len(streams) == 0 {
+		return
+	}
+	if err := s.request(ctx, "SUBSCRIBE", streams); err != nil && ctx.Err() == nil {
+		s.logger.Error("failed to resubscribe streams", zap.Strings("streams", streams), zap.Error(err))
+	}
+}
+
+func (s *StreamClient) stopAll() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = make(map[string]*streamSubscription)
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.stop()
+	}
+}
+
+// wsDepthMessage is depth event as sent by the exchange, with price levels
+// encoded as arrays.
+type wsDepthMessage struct {
+	Event         string      `json:"e"`
+	Time          int64       `json:"E"`
+	Symbol        string      `json:"s"`
+	FirstUpdateID int64       `json:"U"`
+	LastUpdateID  int64       `json:"u"`
+	Bids          [][2]string `json:"b"`
+	Asks          [][2]string `json:"a"`
+}
+
+func (m *wsDepthMessage) event() *extBinanceClient.WsDepthEvent {
+	event := &extBinanceClient.WsDepthEvent{
+		Event:         m.Event,
+		Time:          m.Time,
+		Symbol:        m.Symbol,
+		FirstUpdateID: m.FirstUpdateID,
+		LastUpdateID:  m.LastUpdateID,
+	}
+	for _, bid := range m.Bids {
+		event.Bids = append(event.Bids, extBinanceClient.Bid{Price: bid[0], Quantity: bid[1]})
+	}
+	for _, ask := range m.Asks {
+		event.Asks = append(event.Asks, extBinanceClient.Ask{Price: ask[0], Quantity: ask[1]})
+	}
+	return event
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/asnowflake777/go-binance"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// streamServer answers every request of combined stream protocol and
+// records their methods.
+type streamServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func newStreamServer(t *testing.T) *streamServer {
+	srv := &streamServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var request streamRequest
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			srv.mu.Lock()
+			srv.requests = append(srv.requests, request.Method+" "+strings.Join(request.Params, ","))
+			srv.mu.Unlock()
+			if err := conn.WriteJSON(map[string]interface{}{"result": nil, "id": request.ID}); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (srv *streamServer) endpoint() string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func (srv *streamServer) recorded() []string {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return append([]string(nil), srv.requests...)
+}
+
+func TestStreamClientStartTwice(t *testing.T) {
+	srv := newStreamServer(t)
+	s := NewStreamClient(srv.endpoint(), zap.NewNop(), WebsocketConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	doneC, err := s.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Start(ctx); !errors.Is(err, ErrStreamClientStarted) {
+		t.Errorf("second Start returned %v, want ErrStreamClientStarted", err)
+	}
+	cancel()
+	<-doneC
+	if _, err := s.Start(context.Background()); !errors.Is(err, ErrStreamClientStarted) {
+		t.Errorf("Start of stopped client returned %v, want ErrStreamClientStarted", err)
+	}
+}
+
+func TestStreamClientSubscribeWhileReconnecting(t *testing.T) {
+	srv := newStreamServer(t)
+	s := NewStreamClient(srv.endpoint(), zap.NewNop(), WebsocketConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	doneC, err := s.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.SubscribeTrade(ctx, binance.TradeWebsocketRequest{Symbol: "BTCUSDT"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stream subscribed while connection is down is registered without
+	// request and subscribed by resubscribe.
+	s.mu.Lock()
+	s.connected = false
+	s.mu.Unlock()
+	if _, _, err := s.SubscribeTrade(ctx, binance.TradeWebsocketRequest{Symbol: "ETHUSDT"}); err != nil {
+		t.Fatalf("subscribe while reconnecting: %v", err)
+	}
+	if streams := s.Streams(); len(streams) != 2 {
+		t.Errorf("streams %v", streams)
+	}
+	if requests := srv.recorded(); len(requests) != 1 || requests[0] != "SUBSCRIBE btcusdt@aggTrade" {
+		t.Errorf("requests %v", requests)
+	}
+
+	s.mu.Lock()
+	s.connected = true
+	s.mu.Unlock()
+	s.resubscribe(ctx)
+	requests := srv.recorded()
+	if len(requests) != 2 || !strings.Contains(requests[1], "ethusdt@aggTrade") || !strings.Contains(requests[1], "btcusdt@aggTrade") {
+		t.Errorf("requests %v", requests)
+	}
+	cancel()
+	<-doneC
+}
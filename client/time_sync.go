@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const timeSyncSamples = 3
+
+// ClockDrift represents measured difference between server and local
+// clocks.
+type ClockDrift struct {
+	// Offset is server time minus local time.
+	Offset time.Duration
+	// RoundTrip is latency of the sample Offset was estimated from.
+	RoundTrip time.Duration
+	// SampledAt is local time of the measurement, zero if clock was never
+	// synchronized.
+	SampledAt time.Time
+}
+
+type clock struct {
+	mu    sync.RWMutex
+	drift ClockDrift
+}
+
+func (cl *clock) get() ClockDrift {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	return cl.drift
+}
+
+func (cl *clock) set(drift ClockDrift) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.drift = drift
+}
+
+// WithTimeSync makes client synchronize clock with server every interval
+// until context passed to New is done.
+func WithTimeSync(interval time.Duration) Option {
+	return func(c *Client) {
+		c.timeSyncInterval = interval
+	}
+}
+
+// ClockDrift returns the last measured clock drift.
+func (c *Client) ClockDrift() ClockDrift {
+	return c.clock.get()
+}
+
+// SyncTime samples server time several times and keeps offset estimated from
+// sample with the lowest round trip, assuming symmetric latency. Signed
+// requests with zero Timestamp are stamped with corrected time afterwards.
+func (c *Client) SyncTime(ctx context.Context) (ClockDrift, error) {
+	var best ClockDrift
+	for i := 0; i < timeSyncSamples; i++ {
+		sent := time.Now()
+		serverTime, err := c.Time(ctx)
+		if err != nil {
+			return ClockDrift{}, err
+		}
+		received := time.Now()
+		roundTrip := received.Sub(sent)
+		if i > 0 && roundTrip >= best.RoundTrip {
+			continue
+		}
+		best = ClockDrift{
+			Offset:    serverTime.Sub(sent.Add(roundTrip / 2)),
+			RoundTrip: roundTrip,
+			SampledAt: received,
+		}
+	}
+	c.clock.set(best)
+	return best, nil
+}
+
+func (c *Client) runTimeSync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.SyncTime(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Warn("failed to sync time", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// transport feeds response headers to RateLimiter and records response
+// status for request errors.
+type transport struct {
+	next    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.limiter.Update(res.StatusCode, res.Header)
+	if recorder, ok := req.Context().Value(responseRecorderKey{}).(*responseRecorder); ok {
+		recorder.statusCode = res.StatusCode
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			recorder.retryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+	return res, nil
+}
+
+type responseRecorderKey struct{}
+
+// responseRecorder keeps status and Retry-After of the last response of
+// request made with context returned by withResponseRecorder.
+type responseRecorder struct {
+	statusCode int
+	retryAfter time.Duration
+}
+
+func withResponseRecorder(ctx context.Context) (context.Context, *responseRecorder) {
+	recorder := &responseRecorder{}
+	return context.WithValue(ctx, responseRecorderKey{}, recorder), recorder
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	extBinanceClient "github.com/adshao/go-binance/v2"
+	"github.com/asnowflake777/go-binance"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultKeepAliveInterval is interval between listen key keep-alive
+	// calls. Listen key expires after 60 minutes without keep-alive.
+	DefaultKeepAliveInterval = 30 * time.Minute
+
+	listenKeyExpiredEvent = "listenKeyExpired"
+	userDataRetryDelay    = 5 * time.Second
+	userDataCloseTimeout  = 10 * time.Second
+	keepAliveAttempts     = 3
+)
+
+// UserDataSession manages user data stream on behalf of the caller: it
+// obtains listen key, keeps it alive, recreates it once expired or
+// disconnected and closes it when context is done.
+type UserDataSession struct {
+	client            *Client
+	keepAliveInterval time.Duration
+
+	mu     sync.RWMutex
+	stream *binance.Stream
+}
+
+// NewUserDataSession returns session which keeps listen key alive every
+// keepAliveInterval. Non-positive interval means DefaultKeepAliveInterval.
+func NewUserDataSession(client *Client, keepAliveInterval time.Duration) *UserDataSession {
+	if keepAliveInterval <= 0 {
+		keepAliveInterval = DefaultKeepAliveInterval
+	}
+	return &UserDataSession{
+		client:            client,
+		keepAliveInterval: keepAliveInterval,
+	}
+}
+
+// Stream returns currently used stream or nil if session is not started.
+func (s *UserDataSession) Stream() *binance.Stream {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stream
+}
+
+// Start opens user data stream and serves its events until ctx is done.
+// Returned channels stay the same when listen key is recreated.
+func (s *UserDataSession) Start(ctx context.Context) (chan *binance.UserDataEvent, chan struct{}, error) {
+	buffer := newEventBuffer[*binance.UserDataEvent](s.client.websocket, &s.client.dropped, nil)
+	conn, err := s.connect(ctx, buffer)
+	if err != nil {
+		return nil, nil, err
+	}
+	runDoneC := make(chan struct{})
+	go s.run(ctx, conn, buffer, runDoneC)
+	events, doneC := buffer.forward(ctx, runDoneC)
+	return events, doneC, nil
+}
+
+type userDataConn struct {
+	stream      *binance.Stream
+	doneC       chan struct{}
+	stopC       chan struct{}
+	expiredC    chan struct{}
+	expiredOnce sync.Once
+}
+
+func (conn *userDataConn) stop() {
+	select {
+	case <-conn.doneC:
+	default:
+		close(conn.stopC)
+		<-conn.doneC
+	}
+}
+
+func (s *UserDataSession) connect(ctx context.Context, buffer *eventBuffer[*binance.UserDataEvent]) (*userDataConn, error) {
+	stream, err := s.client.StartUserDataStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn := &userDataConn{
+		stream:   stream,
+		expiredC: make(chan struct{}),
+	}
+	conn.doneC, conn.stopC, err = s.client.serveUserData(stream.ListenKey, func(event *extBinanceClient.WsUserDataEvent) {
+		if event.Event == listenKeyExpiredEvent {
+			conn.expiredOnce.Do(func() { close(conn.expiredC) })
+			return
+		}
+		forwardUserDataEvent(ctx, s.client.logger, buffer, event)
+	})
+	if err != nil {
+		s.close(stream)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.stream = stream
+	s.mu.Unlock()
+	return conn, nil
+}
+
+func (s *UserDataSession) run(ctx context.Context, conn *userDataConn, buffer *eventBuffer[*binance.UserDataEvent], doneC chan struct{}) {
+	defer close(doneC)
+
+	ticker := time.NewTicker(s.keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		expired := false
+		select {
+		case <-ctx.Done():
+			conn.stop()
+			s.close(conn.stream)
+			return
+		case <-ticker.C:
+			if s.keepAlive(ctx, conn.stream) {
+				continue
+			}
+		case <-conn.expiredC:
+			s.client.logger.Info("user data stream listen key expired")
+			expired = true
+		case <-conn.doneC:
+			s.client.logger.Warn("user data websocket disconnected")
+		}
+
+		// Listen key is abandoned, so it's closed unless it's expired.
+		conn.stop()
+		if !expired {
+			s.close(conn.stream)
+		}
+		newConn, ok := s.reconnect(ctx, buffer)
+		if !ok {
+			return
+		}
+		conn = newConn
+		ticker.Reset(s.keepAliveInterval)
+	}
+}
+
+// keepAlive prolongs listen key, retrying transient failures. It returns
+// false once all attempts failed or ctx is done.
+func (s *UserDataSession) keepAlive(ctx context.Context, stream *binance.Stream) bool {
+	for attempt := 1; ; attempt++ {
+		err := s.client.KeepAliveUserDataStream(ctx, stream)
+		if err == nil {
+			return true
+		}
+		s.client.logger.Warn("failed to keep alive user data stream", zap.Int("attempt", attempt), zap.Error(err))
+		if attempt == keepAliveAttempts {
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(userDataRetryDelay):
+		}
+	}
+}
+
+// reconnect recreates stream until it succeeds or ctx is done.
+func (s *UserDataSession) reconnect(ctx context.Context, buffer *eventBuffer[*binance.UserDataEvent]) (*userDataConn, bool) {
+	for {
+		conn, err := s.connect(ctx, buffer)
+		if err == nil {
+			return conn, true
+		}
+		s.client.logger.Error("failed to recreate user data stream", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(userDataRetryDelay):
+		}
+	}
+}
+
+func (s *UserDataSession) close(stream *binance.Stream) {
+	ctx, cancel := context.WithTimeout(context.Background(), userDataCloseTimeout)
+	defer cancel()
+	if err := s.client.CloseUserDataStream(ctx, stream); err != nil {
+		s.client.logger.Warn("failed to close user data stream", zap.Error(err))
+	}
+}
@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	extBinanceClient "github.com/adshao/go-binance/v2"
+	"github.com/asnowflake777/go-binance"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultWebsocketBufferSize is number of events websocket methods buffer
+// for slow consumer by default.
+const DefaultWebsocketBufferSize = 100
+
+// OverflowPolicy defines what websocket methods do with event which doesn't
+// fit into full buffer.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until consumer takes buffered event. Meanwhile
+	// socket isn't read, so exchange may drop slow connection.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered event.
+	OverflowDropOldest
+	// OverflowDropNewest drops event which doesn't fit.
+	OverflowDropNewest
+	// OverflowCoalesce replaces buffered event of the same key with newer
+	// one, even when buffer isn't full: kline updates are keyed by kline,
+	// so consumer gets the latest state of each. Events without key, like
+	// depth diffs or trades, are never coalesced and block on full buffer.
+	OverflowCoalesce
+)
+
+// WebsocketConfig defines buffering of websocket events.
+//
+// Every websocket method returns events channel and done channel. Events
+// channel is closed once socket is closed by server, after buffered events
+// are delivered, or once ctx is done, dropping them. Done channel is closed
+// after that, when all goroutines serving the socket have finished.
+type WebsocketConfig struct {
+	// BufferSize is number of buffered events, non-positive means
+	// DefaultWebsocketBufferSize.
+	BufferSize int
+	Overflow   OverflowPolicy
+}
+
+// WithWebsocketConfig sets buffering of websocket events.
+func WithWebsocketConfig(config WebsocketConfig) Option {
+	return func(c *Client) {
+		c.websocket = config
+	}
+}
+
+// DroppedEvents returns number of websocket events dropped or coalesced by
+// overflow policy.
+func (c *Client) DroppedEvents() uint64 {
+	return c.dropped.Load()
+}
+
+// eventBuffer passes events from socket handler to consumer, applying
+// overflow policy.
+type eventBuffer[T any] struct {
+	size    int
+	policy  OverflowPolicy
+	key     func(T) string
+	dropped *atomic.Uint64
+
+	mu     sync.Mutex
+	items  []T
+	closed bool
+	// ready wakes consumer once event is added, space wakes producer once
+	// event is taken.
+	ready chan struct{}
+	space chan struct{}
+}
+
+func newEventBuffer[T any](config WebsocketConfig, dropped *atomic.Uint64, key func(T) string) *eventBuffer[T] {
+	size := config.BufferSize
+	if size <= 0 {
+		size = DefaultWebsocketBufferSize
+	}
+	return &eventBuffer[T]{
+		size:    size,
+		policy:  config.Overflow,
+		key:     key,
+		dropped: dropped,
+		ready:   make(chan struct{}, 1),
+		space:   make(chan struct{}, 1),
+	}
+}
+
+// push adds event to buffer. Under OverflowBlock it waits for space until
+// ctx is done.
+func (b *eventBuffer[T]) push(ctx context.Context, event T) {
+	b.mu.Lock()
+	if b.policy == OverflowCoalesce && b.key != nil {
+		key := b.key(event)
+		for i, item := range b.items {
+			if b.key(item) == key {
+				b.items[i] = event
+				b.mu.Unlock()
+				b.dropped.Add(1)
+				return
+			}
+		}
+	}
+	for len(b.items) >= b.size {
+		switch b.policy {
+		case OverflowDropNewest:
+			b.mu.Unlock()
+			b.dropped.Add(1)
+			return
+		case OverflowDropOldest:
+			var zero T
+			b.items[0] = zero
+			b.items = b.items[1:]
+			b.dropped.Add(1)
+			continue
+		}
+		b.mu.Unlock()
+		select {
+		case <-b.space:
+		case <-ctx.Done():
+			return
+		}
+		b.mu.Lock()
+	}
+	b.items = append(b.items, event)
+	b.mu.Unlock()
+	signal(b.ready)
+}
+
+// close makes run close events channel once buffer is drained.
+func (b *eventBuffer[T]) close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	signal(b.ready)
+}
+
+// run delivers buffered events to events channel and closes it once buffer
+// is closed and drained or ctx is done.
+func (b *eventBuffer[T]) run(ctx context.Context, events chan T) {
+	defer close(events)
+	for {
+		b.mu.Lock()
+		if len(b.items) == 0 {
+			closed := b.closed
+			b.mu.Unlock()
+			if closed {
+				return
+			}
+			select {
+			case <-b.ready:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		event := b.items[0]
+		var zero T
+		b.items[0] = zero
+		b.items = b.items[1:]
+		b.mu.Unlock()
+		signal(b.space)
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forward starts delivering buffered events to returned events channel.
+// Buffer is closed once sourceDoneC is closed, returned done channel is
+// closed after events channel and sourceDoneC.
+func (b *eventBuffer[T]) forward(ctx context.Context, sourceDoneC chan struct{}) (chan T, chan struct{}) {
+	events := make(chan T)
+	doneC := make(chan struct{})
+	go func() {
+		<-sourceDoneC
+		b.close()
+	}()
+	go func() {
+		b.run(ctx, events)
+		<-sourceDoneC
+		close(doneC)
+	}()
+	return events, doneC
+}
+
+// stopOnDone stops socket once ctx is done, unless it's closed before.
+func stopOnDone(ctx context.Context, stopC, doneC chan struct{}) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stopC)
+		case <-doneC:
+		}
+	}()
+}
+
+func signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+func klineEventKey(event *binance.KlineEvent) string {
+	return event.Symbol + "@" + string(event.Interval) + "@" + strconv.FormatInt(event.OpenTime.UnixMilli(), 10)
+}
+
+func ticker24EventKey(event *binance.Ticker24Event) string {
+	return event.Symbol
+}
+
+func miniTickerEventKey(event *binance.MiniTickerEvent) string {
+	return event.Symbol
+}
+
+func bookTickerEventKey(event *binance.BookTickerEvent) string {
+	return event.BookTicker.Symbol
+}
+
+// serveStream serves raw messages of single stream, for streams the
+// underlying client has no method for.
+func serveStream(stream string, handler func(message []byte), errHandler func(err error)) (chan struct{}, chan struct{}, error) {
+	endpoint := extBinanceClient.BaseWsMainURL
+	if extBinanceClient.UseTestnet {
+		endpoint = extBinanceClient.BaseWsTestnetURL
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(endpoint+"/"+stream, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	doneC := make(chan struct{})
+	stopC := make(chan struct{})
+	go func() {
+		defer close(doneC)
+		stopped := make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+				close(stopped)
+			case <-doneC:
+			}
+			conn.Close()
+		}()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				select {
+				case <-stopped:
+				default:
+					errHandler(err)
+				}
+				return
+			}
+			handler(message)
+		}
+	}()
+	return doneC, stopC, nil
+}
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	extBinanceClient "github.com/adshao/go-binance/v2"
+	"github.com/asnowflake777/go-binance"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// drain returns all events of buffer closed before forwarding.
+func drain(t *testing.T, b *eventBuffer[int]) []int {
+	t.Helper()
+	sourceDoneC := make(chan struct{})
+	close(sourceDoneC)
+	events, doneC := b.forward(context.Background(), sourceDoneC)
+	var got []int
+	for event := range events {
+		got = append(got, event)
+	}
+	<-doneC
+	return got
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEventBufferOverflow(t *testing.T) {
+	parity := func(event int) string {
+		return strconv.Itoa(event % 2)
+	}
+	tests := []struct {
+		name        string
+		policy      OverflowPolicy
+		key         func(int) string
+		events      []int
+		want        []int
+		wantDropped uint64
+	}{
+		{"drop oldest", OverflowDropOldest, nil, []int{0, 1, 2, 3, 4}, []int{2, 3, 4}, 2},
+		{"drop newest", OverflowDropNewest, nil, []int{0, 1, 2, 3, 4}, []int{0, 1, 2}, 2},
+		{"coalesce", OverflowCoalesce, parity, []int{0, 1, 2, 3, 4}, []int{4, 3}, 3},
+		{"coalesce below size", OverflowCoalesce, parity, []int{0, 2}, []int{2}, 1},
+		{"fits", OverflowDropNewest, nil, []int{0, 1}, []int{0, 1}, 0},
+	}
+	for _, tt := range tests {
+		c := &Client{websocket: WebsocketConfig{BufferSize: 3, Overflow: tt.policy}}
+		b := newEventBuffer(c.websocket, &c.dropped, tt.key)
+		for _, event := range tt.events {
+			b.push(context.Background(), event)
+		}
+		if got := drain(t, b); !equalInts(got, tt.want) {
+			t.Errorf("%s: events %v, want %v", tt.name, got, tt.want)
+		}
+		if got := c.DroppedEvents(); got != tt.wantDropped {
+			t.Errorf("%s: %d events dropped, want %d", tt.name, got, tt.wantDropped)
+		}
+	}
+}
+
+func TestEventBufferBlock(t *testing.T) {
+	for _, config := range []WebsocketConfig{
+		{BufferSize: 1, Overflow: OverflowBlock},
+		// Events without key aren't coalesced and block.
+		{BufferSize: 1, Overflow: OverflowCoalesce},
+	} {
+		c := &Client{websocket: config}
+		b := newEventBuffer[int](c.websocket, &c.dropped, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		b.push(ctx, 1)
+		pushed := make(chan struct{})
+		go func() {
+			b.push(ctx, 2)
+			close(pushed)
+		}()
+		select {
+		case <-pushed:
+			t.Fatalf("overflow %d: push didn't wait for space", config.Overflow)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		// Taken event makes space.
+		sourceDoneC := make(chan struct{})
+		events, doneC := b.forward(ctx, sourceDoneC)
+		if event := <-events; event != 1 {
+			t.Errorf("overflow %d: first event %d", config.Overflow, event)
+		}
+		<-pushed
+		if event := <-events; event != 2 {
+			t.Errorf("overflow %d: second event %d", config.Overflow, event)
+		}
+
+		// Blocked push returns once ctx is done.
+		b.push(ctx, 3)
+		go func() {
+			b.push(ctx, 4)
+			b.push(ctx, 5)
+			close(sourceDoneC)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		for range events {
+		}
+		<-doneC
+		if c.DroppedEvents() != 0 {
+			t.Errorf("overflow %d: %d events dropped", config.Overflow, c.DroppedEvents())
+		}
+	}
+}
+
+// aggTradeServer serves given number of aggregate trade events to every
+// connection, then closes it if closeAfter is set or waits for client to
+// close it.
+func aggTradeServer(t *testing.T, events int, closeAfter bool) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for i := 1; i <= events; i++ {
+			message := `{"e":"aggTrade","E":1,"s":"BTCUSDT","a":` + strconv.Itoa(i) +
+				`,"p":"1.5","q":"2","f":1,"l":1,"T":1,"m":true,"M":true}`
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+				return
+			}
+		}
+		if closeAfter {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	mainURL := extBinanceClient.BaseWsMainURL
+	extBinanceClient.BaseWsMainURL = "ws" + strings.TrimPrefix(srv.URL, "http")
+	t.Cleanup(func() {
+		extBinanceClient.BaseWsMainURL = mainURL
+		srv.Close()
+	})
+}
+
+// checkGoroutines fails test if number of goroutines doesn't drop back to
+// baseline.
+func checkGoroutines(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines left, want %d:\n%s", runtime.NumGoroutine(), baseline, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func newTestClient(config WebsocketConfig) *Client {
+	return New(context.Background(), "", "", zap.NewNop(), WithWebsocketConfig(config))
+}
+
+func TestWebsocketShutdownOnCancel(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowBlock, OverflowDropOldest, OverflowDropNewest, OverflowCoalesce} {
+		aggTradeServer(t, 50, false)
+		c := newTestClient(WebsocketConfig{BufferSize: 2, Overflow: policy})
+		baseline := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(context.Background())
+		events, doneC, err := c.TradeWebsocket(ctx, binance.TradeWebsocketRequest{Symbol: "BTCUSDT"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event := <-events; event == nil {
+			t.Fatalf("overflow %d: events closed before cancel", policy)
+		}
+		// Consumer stops reading while buffer is full.
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		select {
+		case <-doneC:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("overflow %d: websocket not done after cancel", policy)
+		}
+		for range events {
+		}
+		checkGoroutines(t, baseline)
+	}
+}
+
+func TestWebsocketShutdownOnServerClose(t *testing.T) {
+	aggTradeServer(t, 3, true)
+	c := newTestClient(WebsocketConfig{BufferSize: 10})
+	baseline := runtime.NumGoroutine()
+	events, doneC, err := c.TradeWebsocket(context.Background(), binance.TradeWebsocketRequest{Symbol: "BTCUSDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Events buffered before close are delivered.
+	time.Sleep(50 * time.Millisecond)
+	var got []int
+	for event := range events {
+		got = append(got, int(event.ID))
+	}
+	if !equalInts(got, []int{1, 2, 3}) {
+		t.Errorf("events %v", got)
+	}
+	select {
+	case <-doneC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("websocket not done after server closed it")
+	}
+	checkGoroutines(t, baseline)
+}
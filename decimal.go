@@ -0,0 +1,385 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal represents arbitrary-precision decimal number used for prices and
+// quantities.
+//
+// Decimal is immutable, every operation returns new value. Zero value is 0.
+type Decimal struct {
+	// coef is unscaled value, nil means zero.
+	coef *big.Int
+	// scale is number of digits after decimal point.
+	scale int32
+}
+
+var (
+	ErrInvalidDecimal = errors.New("invalid decimal")
+	ErrDivisionByZero = errors.New("decimal division by zero")
+
+	bigTen = big.NewInt(10)
+)
+
+// maxExponent bounds exponent accepted by ParseDecimal, so that untrusted
+// input can't make huge numbers or strings.
+const maxExponent = 1000
+
+// NewDecimal returns value * 10^-scale.
+func NewDecimal(value int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(value), scale: scale}.normalize()
+}
+
+// NewDecimalFromFloat returns shortest decimal representation of f. NaN and
+// infinities return ErrInvalidDecimal.
+func NewDecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("%w: %v", ErrInvalidDecimal, f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// ParseDecimal parses decimal from string like "0.00100000", "-12" or
+// "1.5e-8". Exponent is limited to ±1000.
+func ParseDecimal(s string) (Decimal, error) {
+	if s == "" {
+		return Decimal{}, fmt.Errorf("%w: empty string", ErrInvalidDecimal)
+	}
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+		mantissa = s[:i]
+	}
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+	digits := intPart + fracPart
+	if strings.TrimLeft(digits, "+-") == "" || strings.ContainsAny(fracPart, "+-") {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}.normalize(), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input. It's
+// intended for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String returns decimal in plain notation without trailing zeros, the form
+// accepted by the exchange.
+func (d Decimal) String() string {
+	if d.IsZero() {
+		return "0"
+	}
+	digits := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	scale := int(d.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// StringFixed returns decimal rounded to given number of places, keeping
+// trailing zeros.
+func (d Decimal) StringFixed(places int32) string {
+	s := d.Round(places).String()
+	if places <= 0 {
+		return s
+	}
+	point := strings.IndexByte(s, '.')
+	if point < 0 {
+		return s + "." + strings.Repeat("0", int(places))
+	}
+	return s + strings.Repeat("0", int(places)-(len(s)-point-1))
+}
+
+// Float64 returns nearest float64 value.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Scale returns number of significant digits after decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.coef == nil || d.coef.Sign() == 0
+}
+
+// Sign returns -1, 0 or 1 depending on sign of d.
+func (d Decimal) Sign() int {
+	if d.coef == nil {
+		return 0
+	}
+	return d.coef.Sign()
+}
+
+// Cmp compares d and d2 and returns -1, 0 or 1.
+func (d Decimal) Cmp(d2 Decimal) int {
+	a, b := align(d, d2)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and d2 are equal.
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan reports whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan reports whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	if d.IsZero() {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Neg(d.coef), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.Sign() >= 0 {
+		return d
+	}
+	return d.Neg()
+}
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	a, b := align(d, d2)
+	return Decimal{coef: a.Add(a, b), scale: maxScale(d, d2)}.normalize()
+}
+
+// Sub returns d - d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	a, b := align(d, d2)
+	return Decimal{coef: a.Sub(a, b), scale: maxScale(d, d2)}.normalize()
+}
+
+// Mul returns d * d2.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	if d.IsZero() || d2.IsZero() {
+		return Decimal{}
+	}
+	coef := new(big.Int).Mul(d.coef, d2.coef)
+	return Decimal{coef: coef, scale: d.scale + d2.scale}.normalize()
+}
+
+// Div returns d / d2 rounded half away from zero to given number of places.
+// Division by zero returns ErrDivisionByZero.
+func (d Decimal) Div(d2 Decimal, places int32) (Decimal, error) {
+	if d2.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	if d.IsZero() {
+		return Decimal{}, nil
+	}
+	// d/d2 = (c1/c2) * 10^(s2-s1), result scaled by 10^places.
+	num := new(big.Int).Set(d.coef)
+	den := new(big.Int).Set(d2.coef)
+	if exp := int64(places) - int64(d.scale) + int64(d2.scale); exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+	return Decimal{coef: quoRound(num, den), scale: places}.normalize(), nil
+}
+
+// Round rounds d half away from zero to given number of places.
+func (d Decimal) Round(places int32) Decimal {
+	if d.IsZero() || d.scale <= places {
+		return d
+	}
+	den := pow10(int64(d.scale - places))
+	return Decimal{coef: quoRound(new(big.Int).Set(d.coef), den), scale: places}.normalize()
+}
+
+// Truncate rounds d toward zero to given number of places.
+func (d Decimal) Truncate(places int32) Decimal {
+	if d.IsZero() || d.scale <= places {
+		return d
+	}
+	den := pow10(int64(d.scale - places))
+	return Decimal{coef: new(big.Int).Quo(d.coef, den), scale: places}.normalize()
+}
+
+// TruncateToStep returns multiple of step nearest to d toward zero.
+// Non-positive step returns d unchanged.
+func (d Decimal) TruncateToStep(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b := align(d, step)
+	quo := a.Quo(a, b)
+	return Decimal{coef: quo.Mul(quo, b), scale: maxScale(d, step)}.normalize()
+}
+
+// RoundToStep returns multiple of step nearest to d, rounding half away
+// from zero. Non-positive step returns d unchanged.
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b := align(d, step)
+	quo := quoRound(a, b)
+	return Decimal{coef: quo.Mul(quo, b), scale: maxScale(d, step)}.normalize()
+}
+
+// IsMultipleOf reports whether d is multiple of step. Non-positive step
+// always matches.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.Sign() <= 0 {
+		return true
+	}
+	a, b := align(d, step)
+	return a.Rem(a, b).Sign() == 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes decimal as JSON string to keep precision.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts both JSON strings and numbers.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// normalize strips trailing zeros after decimal point.
+func (d Decimal) normalize() Decimal {
+	if d.IsZero() {
+		return Decimal{}
+	}
+	if d.scale <= 0 {
+		if d.scale < 0 {
+			d.coef = new(big.Int).Mul(d.coef, pow10(int64(-d.scale)))
+			d.scale = 0
+		}
+		return d
+	}
+	coef := new(big.Int).Set(d.coef)
+	rem := new(big.Int)
+	quo := new(big.Int)
+	scale := d.scale
+	for scale > 0 {
+		quo.QuoRem(coef, bigTen, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		coef.Set(quo)
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+func (d Decimal) rescale(scale int32) *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	coef := new(big.Int).Set(d.coef)
+	if scale > d.scale {
+		coef.Mul(coef, pow10(int64(scale-d.scale)))
+	}
+	return coef
+}
+
+func align(d, d2 Decimal) (*big.Int, *big.Int) {
+	scale := maxScale(d, d2)
+	return d.rescale(scale), d2.rescale(scale)
+}
+
+func maxScale(d, d2 Decimal) int32 {
+	if d.scale > d2.scale {
+		return d.scale
+	}
+	return d2.scale
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+// quoRound returns num/den rounded half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+	twiceRem := rem.Abs(rem)
+	twiceRem.Lsh(twiceRem, 1)
+	if twiceRem.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
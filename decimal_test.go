@@ -0,0 +1,199 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"0.00000000", "0"},
+		{"0.00100000", "0.001"},
+		{"-12", "-12"},
+		{"+7.50", "7.5"},
+		{"-0.5", "-0.5"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{"1.5e-8", "0.000000015"},
+		{"1.5E3", "1500"},
+		{"-2e+2", "-200"},
+		{"12.5e-1", "1.25"},
+		{"1e-1000", "0." + strings.Repeat("0", 999) + "1"},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q): %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		again, err := ParseDecimal(d.String())
+		if err != nil || !again.Equal(d) {
+			t.Errorf("round trip of %q: %s, %v", tt.in, again, err)
+		}
+	}
+}
+
+func TestParseDecimalInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", "-", ".", "abc", "1.2.3", "1-2", "1.-2", "1e", "e5", "1e1.5",
+		"0.1e-2147483647",
+		"12.5e-2147483647",
+		"1e-2147483647",
+		"1e2147483647",
+		"1e1001",
+		"1e-1001",
+		"1e99999999999",
+	} {
+		if d, err := ParseDecimal(in); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("ParseDecimal(%q) = %s, %v, want ErrInvalidDecimal", in, d, err)
+		}
+	}
+}
+
+func TestNewDecimalFromFloat(t *testing.T) {
+	d, err := NewDecimalFromFloat(0.1)
+	if err != nil || d.String() != "0.1" {
+		t.Errorf("NewDecimalFromFloat(0.1) = %s, %v", d, err)
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewDecimalFromFloat(f); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("NewDecimalFromFloat(%v) = %v, want ErrInvalidDecimal", f, err)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"add", MustParseDecimal("0.1").Add(MustParseDecimal("0.2")), "0.3"},
+		{"sub", MustParseDecimal("1").Sub(MustParseDecimal("1.0001")), "-0.0001"},
+		{"mul", MustParseDecimal("-1.5").Mul(MustParseDecimal("0.02")), "-0.03"},
+		{"neg", MustParseDecimal("2.5").Neg(), "-2.5"},
+		{"abs", MustParseDecimal("-2.5").Abs(), "2.5"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int32
+		want   string
+	}{
+		{"1", "3", 4, "0.3333"},
+		{"2", "3", 4, "0.6667"},
+		{"-2", "3", 4, "-0.6667"},
+		{"1", "8", 2, "0.13"},
+		{"-1", "8", 2, "-0.13"},
+		{"10", "0.25", 0, "40"},
+		{"0", "7", 2, "0"},
+	}
+	for _, tt := range tests {
+		got, err := MustParseDecimal(tt.a).Div(MustParseDecimal(tt.b), tt.places)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s / %s (%d) = %s, %v, want %s", tt.a, tt.b, tt.places, got, err, tt.want)
+		}
+	}
+	if _, err := MustParseDecimal("1").Div(Decimal{}, 2); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("division by zero: %v", err)
+	}
+}
+
+func TestDecimalRoundTruncate(t *testing.T) {
+	tests := []struct {
+		in       string
+		places   int32
+		round    string
+		truncate string
+	}{
+		{"1.25", 1, "1.3", "1.2"},
+		{"-1.25", 1, "-1.3", "-1.2"},
+		{"1.24", 1, "1.2", "1.2"},
+		{"1.5", 0, "2", "1"},
+		{"-1.5", 0, "-2", "-1"},
+		{"155", -1, "160", "150"},
+		{"0.1", 3, "0.1", "0.1"},
+	}
+	for _, tt := range tests {
+		d := MustParseDecimal(tt.in)
+		if got := d.Round(tt.places).String(); got != tt.round {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.in, tt.places, got, tt.round)
+		}
+		if got := d.Truncate(tt.places).String(); got != tt.truncate {
+			t.Errorf("Truncate(%s, %d) = %s, want %s", tt.in, tt.places, got, tt.truncate)
+		}
+	}
+	if got := MustParseDecimal("1.5").StringFixed(3); got != "1.500" {
+		t.Errorf("StringFixed = %s", got)
+	}
+}
+
+func TestDecimalSteps(t *testing.T) {
+	tests := []struct {
+		in, step  string
+		truncated string
+		rounded   string
+		multiple  bool
+	}{
+		{"1.23456", "0.01", "1.23", "1.23", false},
+		{"1.235", "0.01", "1.23", "1.24", false},
+		{"-1.235", "0.01", "-1.23", "-1.24", false},
+		{"1.25", "0.05", "1.25", "1.25", true},
+		{"7", "2.5", "5", "7.5", false},
+		{"0.004", "0.01", "0", "0", false},
+		{"3.3", "0", "3.3", "3.3", true},
+	}
+	for _, tt := range tests {
+		d, step := MustParseDecimal(tt.in), MustParseDecimal(tt.step)
+		if got := d.TruncateToStep(step).String(); got != tt.truncated {
+			t.Errorf("TruncateToStep(%s, %s) = %s, want %s", tt.in, tt.step, got, tt.truncated)
+		}
+		if got := d.RoundToStep(step).String(); got != tt.rounded {
+			t.Errorf("RoundToStep(%s, %s) = %s, want %s", tt.in, tt.step, got, tt.rounded)
+		}
+		if got := d.IsMultipleOf(step); got != tt.multiple {
+			t.Errorf("IsMultipleOf(%s, %s) = %v, want %v", tt.in, tt.step, got, tt.multiple)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Str  Decimal `json:"str"`
+		Num  Decimal `json:"num"`
+		Exp  Decimal `json:"exp"`
+		Null Decimal `json:"null"`
+	}
+	data := []byte(`{"str":"0.00100000","num":-12.5,"exp":1e-3,"null":null}`)
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Str.String() != "0.001" || v.Num.String() != "-12.5" || v.Exp.String() != "0.001" || !v.Null.IsZero() {
+		t.Errorf("unmarshal = %s %s %s %s", v.Str, v.Num, v.Exp, v.Null)
+	}
+	out, err := json.Marshal(v.Num)
+	if err != nil || string(out) != `"-12.5"` {
+		t.Errorf("marshal = %s, %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"str":"1e-2147483647"}`), &v); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("unmarshal of huge exponent: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Error codes returned by the exchange.
+const (
+	CodeUnknown             = -1000
+	CodeDisconnected        = -1001
+	CodeUnauthorized        = -1002
+	CodeTooManyRequests     = -1003
+	CodeUnexpectedResponse  = -1006
+	CodeTimeout             = -1007
+	CodeServerBusy          = -1008
+	CodeInvalidMessage      = -1013
+	CodeTooManyOrders       = -1015
+	CodeServiceShuttingDown = -1016
+	CodeInvalidTimestamp    = -1021
+	CodeInvalidSignature    = -1022
+	CodeNewOrderRejected    = -2010
+	CodeCancelRejected      = -2011
+	CodeNoSuchOrder         = -2013
+	CodeBadAPIKeyFormat     = -2014
+	CodeRejectedAPIKey      = -2015
+)
+
+// Sentinel errors matched by Error with errors.Is.
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrUnknownOrder        = errors.New("unknown order")
+	ErrInvalidTimestamp    = errors.New("timestamp outside of recvWindow")
+	ErrTooManyRequests     = errors.New("too many requests")
+	ErrIPBanned            = errors.New("IP banned")
+	ErrFilterFailure       = errors.New("filter failure")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrUnauthorized        = errors.New("unauthorized")
+)
+
+// Error represents Client error structure with error code and message.
+// HTTPStatus is status of response which carried the error and RetryAfter is
+// its Retry-After header, both zero when unknown.
+type Error struct {
+	Code       int           `json:"code"`
+	Message    string        `json:"msg"`
+	HTTPStatus int           `json:"-"`
+	RetryAfter time.Duration `json:"-"`
+}
+
+// Error returns formatted error message.
+func (e Error) Error() string {
+	if e.Code == 0 && e.HTTPStatus != 0 {
+		return fmt.Sprintf("http %d: %s", e.HTTPStatus, e.Message)
+	}
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// Is reports whether e belongs to class of sentinel error target.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrInsufficientBalance:
+		return e.Code == CodeNewOrderRejected && strings.Contains(strings.ToLower(e.Message), "insufficient balance")
+	case ErrUnknownOrder:
+		return e.Code == CodeNoSuchOrder ||
+			e.Code == CodeCancelRejected && strings.Contains(e.Message, "Unknown order")
+	case ErrInvalidTimestamp:
+		return e.Code == CodeInvalidTimestamp
+	case ErrTooManyRequests:
+		return e.Code == CodeTooManyRequests || e.Code == CodeTooManyOrders ||
+			e.HTTPStatus == http.StatusTooManyRequests
+	case ErrIPBanned:
+		return e.HTTPStatus == http.StatusTeapot
+	case ErrFilterFailure:
+		return e.Filter() != ""
+	case ErrInvalidSignature:
+		return e.Code == CodeInvalidSignature
+	case ErrUnauthorized:
+		return e.Code == CodeUnauthorized || e.Code == CodeBadAPIKeyFormat || e.Code == CodeRejectedAPIKey ||
+			e.HTTPStatus == http.StatusUnauthorized
+	}
+	return false
+}
+
+// Filter returns name of failed filter like "PRICE_FILTER" or empty string
+// if e isn't filter failure.
+func (e Error) Filter() string {
+	const prefix = "Filter failure: "
+	i := strings.Index(e.Message, prefix)
+	if i < 0 {
+		return ""
+	}
+	return strings.TrimSpace(e.Message[i+len(prefix):])
+}
+
+// IsRetryable reports whether request failed with err may succeed when
+// repeated later. It doesn't tell whether repeating is safe: non-idempotent
+// requests like order placement may have been executed despite the error.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	var apiErr Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case CodeUnknown, CodeDisconnected, CodeTooManyRequests, CodeUnexpectedResponse, CodeTimeout,
+			CodeServerBusy, CodeTooManyOrders, CodeServiceShuttingDown, CodeInvalidTimestamp:
+			return true
+		}
+		if apiErr.HTTPStatus == http.StatusTeapot {
+			return false
+		}
+		return apiErr.HTTPStatus == http.StatusTooManyRequests || apiErr.HTTPStatus >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
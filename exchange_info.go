@@ -0,0 +1,241 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SymbolStatus represents symbol status enum.
+type SymbolStatus string
+
+// RateLimitType represents rate limit type enum.
+type RateLimitType string
+
+// RateLimitInterval represents rate limit interval enum.
+type RateLimitInterval string
+
+var (
+	SymbolStatusPreTrading   = SymbolStatus("PRE_TRADING")
+	SymbolStatusTrading      = SymbolStatus("TRADING")
+	SymbolStatusPostTrading  = SymbolStatus("POST_TRADING")
+	SymbolStatusEndOfDay     = SymbolStatus("END_OF_DAY")
+	SymbolStatusHalt         = SymbolStatus("HALT")
+	SymbolStatusAuctionMatch = SymbolStatus("AUCTION_MATCH")
+	SymbolStatusBreak        = SymbolStatus("BREAK")
+
+	RateLimitTypeRequestWeight = RateLimitType("REQUEST_WEIGHT")
+	RateLimitTypeOrders        = RateLimitType("ORDERS")
+	RateLimitTypeRawRequests   = RateLimitType("RAW_REQUESTS")
+
+	RateLimitIntervalSecond = RateLimitInterval("SECOND")
+	RateLimitIntervalMinute = RateLimitInterval("MINUTE")
+	RateLimitIntervalDay    = RateLimitInterval("DAY")
+)
+
+// ErrUnknownSymbol is returned when symbol is missing in exchange info.
+var ErrUnknownSymbol = errors.New("unknown symbol")
+
+// ExchangeInfoRequest represents ExchangeInfo request data. Empty Symbols
+// requests all symbols.
+type ExchangeInfoRequest struct {
+	Symbols []string
+}
+
+// ExchangeInfo represents exchange trading rules and symbol information.
+type ExchangeInfo struct {
+	Timezone   string
+	ServerTime time.Time
+	RateLimits []*RateLimit
+	Symbols    []*SymbolInfo
+
+	symbolsOnce   sync.Once
+	symbolsByName map[string]*SymbolInfo
+}
+
+// Symbol looks up symbol information by name. Index is built on first call,
+// so Symbols must not be modified afterwards.
+func (ei *ExchangeInfo) Symbol(symbol string) (*SymbolInfo, bool) {
+	ei.symbolsOnce.Do(func() {
+		ei.symbolsByName = make(map[string]*SymbolInfo, len(ei.Symbols))
+		for _, si := range ei.Symbols {
+			ei.symbolsByName[si.Symbol] = si
+		}
+	})
+	si, ok := ei.symbolsByName[symbol]
+	return si, ok
+}
+
+// RateLimit represents single rate limit definition.
+type RateLimit struct {
+	Type        RateLimitType
+	Interval    RateLimitInterval
+	IntervalNum int
+	Limit       int
+}
+
+// Duration returns length of rate limit window.
+func (rl *RateLimit) Duration() time.Duration {
+	var unit time.Duration
+	switch rl.Interval {
+	case RateLimitIntervalSecond:
+		unit = time.Second
+	case RateLimitIntervalMinute:
+		unit = time.Minute
+	case RateLimitIntervalDay:
+		unit = 24 * time.Hour
+	}
+	return time.Duration(rl.IntervalNum) * unit
+}
+
+// SymbolInfo represents symbol metadata and trading rules.
+type SymbolInfo struct {
+	Symbol                     string
+	Status                     SymbolStatus
+	BaseAsset                  string
+	BaseAssetPrecision         int
+	QuoteAsset                 string
+	QuotePrecision             int
+	OrderTypes                 []OrderType
+	IcebergAllowed             bool
+	OCOAllowed                 bool
+	QuoteOrderQtyMarketAllowed bool
+	IsSpotTradingAllowed       bool
+	Filters                    SymbolFilters
+}
+
+// AllowsOrderType reports whether orders of type t can be placed on symbol.
+func (si *SymbolInfo) AllowsOrderType(t OrderType) bool {
+	for _, orderType := range si.OrderTypes {
+		if orderType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SymbolFilters groups trading filters of symbol. Filters not defined for
+// symbol are nil.
+type SymbolFilters struct {
+	Price         *PriceFilter
+	LotSize       *LotSizeFilter
+	MarketLotSize *LotSizeFilter
+	MinNotional   *MinNotionalFilter
+	Notional      *NotionalFilter
+	PercentPrice  *PercentPriceFilter
+	MaxNumOrders  *MaxNumOrdersFilter
+	TrailingDelta *TrailingDeltaFilter
+}
+
+// PriceFilter represents PRICE_FILTER. Zero value of any field means the
+// rule is disabled.
+type PriceFilter struct {
+	MinPrice Decimal
+	MaxPrice Decimal
+	TickSize Decimal
+}
+
+// LotSizeFilter represents LOT_SIZE and MARKET_LOT_SIZE filters.
+type LotSizeFilter struct {
+	MinQty   Decimal
+	MaxQty   Decimal
+	StepSize Decimal
+}
+
+// MinNotionalFilter represents MIN_NOTIONAL filter.
+type MinNotionalFilter struct {
+	MinNotional   Decimal
+	ApplyToMarket bool
+	AvgPriceMins  int
+}
+
+// NotionalFilter represents NOTIONAL filter.
+type NotionalFilter struct {
+	MinNotional      Decimal
+	ApplyMinToMarket bool
+	MaxNotional      Decimal
+	ApplyMaxToMarket bool
+	AvgPriceMins     int
+}
+
+// PercentPriceFilter represents PERCENT_PRICE filter.
+type PercentPriceFilter struct {
+	MultiplierUp   Decimal
+	MultiplierDown Decimal
+	AvgPriceMins   int
+}
+
+// MaxNumOrdersFilter represents MAX_NUM_ORDERS filter.
+type MaxNumOrdersFilter struct {
+	MaxNumOrders int
+}
+
+// TrailingDeltaFilter represents TRAILING_DELTA filter, bounds of
+// TrailingDelta in basis points. Above bounds apply to orders triggered by
+// price rising, that is buy stop loss and sell take profit, below bounds to
+// the others.
+type TrailingDeltaFilter struct {
+	MinTrailingAboveDelta int
+	MaxTrailingAboveDelta int
+	MinTrailingBelowDelta int
+	MaxTrailingBelowDelta int
+}
+
+// ExchangeInfoCache keeps exchange info fetched from Client and refreshes it
+// once it's older than TTL.
+type ExchangeInfoCache struct {
+	client Client
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	info      *ExchangeInfo
+	fetchedAt time.Time
+}
+
+// NewExchangeInfoCache returns cache for exchange info of all symbols.
+// Non-positive ttl means info is fetched once and never refreshed.
+func NewExchangeInfoCache(client Client, ttl time.Duration) *ExchangeInfoCache {
+	return &ExchangeInfoCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// ExchangeInfo returns cached exchange info, fetching it when missing or
+// expired.
+func (c *ExchangeInfoCache) ExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.info != nil && (c.ttl <= 0 || time.Since(c.fetchedAt) < c.ttl) {
+		return c.info, nil
+	}
+	info, err := c.client.ExchangeInfo(ctx, ExchangeInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	c.info = info
+	c.fetchedAt = time.Now()
+	return info, nil
+}
+
+// Symbol returns cached symbol information or ErrUnknownSymbol.
+func (c *ExchangeInfoCache) Symbol(ctx context.Context, symbol string) (*SymbolInfo, error) {
+	info, err := c.ExchangeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	si, ok := info.Symbol(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	return si, nil
+}
+
+// Invalidate drops cached exchange info, so next call fetches it again.
+func (c *ExchangeInfoCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.info = nil
+}
@@ -0,0 +1,39 @@
+package binance
+
+import "context"
+
+// fakeClient is Client whose methods call matching function fields. Calls
+// of methods without function set panic on nil embedded Client.
+type fakeClient struct {
+	Client
+	ping             func(ctx context.Context) error
+	orderBook        func(ctx context.Context, obr OrderBookRequest) (*OrderBook, error)
+	klines           func(ctx context.Context, kr KlinesRequest) ([]*Kline, error)
+	historicalTrades func(ctx context.Context, htr HistoricalTradesRequest) ([]*MarketTrade, error)
+	newOrder         func(ctx context.Context, nor NewOrderRequest) (*ProcessedOrder, error)
+	queryOrder       func(ctx context.Context, qor QueryOrderRequest) (*ExecutedOrder, error)
+}
+
+func (c *fakeClient) Ping(ctx context.Context) error {
+	return c.ping(ctx)
+}
+
+func (c *fakeClient) OrderBook(ctx context.Context, obr OrderBookRequest) (*OrderBook, error) {
+	return c.orderBook(ctx, obr)
+}
+
+func (c *fakeClient) HistoricalTrades(ctx context.Context, htr HistoricalTradesRequest) ([]*MarketTrade, error) {
+	return c.historicalTrades(ctx, htr)
+}
+
+func (c *fakeClient) Klines(ctx context.Context, kr KlinesRequest) ([]*Kline, error) {
+	return c.klines(ctx, kr)
+}
+
+func (c *fakeClient) NewOrder(ctx context.Context, nor NewOrderRequest) (*ProcessedOrder, error) {
+	return c.newOrder(ctx, nor)
+}
+
+func (c *fakeClient) QueryOrder(ctx context.Context, qor QueryOrderRequest) (*ExecutedOrder, error) {
+	return c.queryOrder(ctx, qor)
+}
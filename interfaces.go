@@ -9,7 +9,8 @@ import (
 //
 // Read web documentation for more endpoints descriptions and list of
 // mandatory and optional params. Wrapper is not responsible for client-side
-// validation and only sends requests further.
+// validation and only sends requests further, wrap it with ValidatingClient
+// to check orders against symbol filters.
 //
 // For each API-defined enum there's a special type and list of defined
 // enum values to be used.
@@ -18,18 +19,26 @@ type Client interface {
 	Ping(ctx context.Context) error
 	// Time returns server time.
 	Time(ctx context.Context) (time.Time, error)
+	// ExchangeInfo returns trading rules and symbol information.
+	ExchangeInfo(ctx context.Context, eir ExchangeInfoRequest) (*ExchangeInfo, error)
 	// OrderBook returns list of orders.
 	OrderBook(ctx context.Context, obr OrderBookRequest) (*OrderBook, error)
 	// AggTrades returns compressed/aggregate list of trades.
 	AggTrades(ctx context.Context, atr AggTradesRequest) ([]*AggTrade, error)
+	// RecentTrades returns the most recent trades.
+	RecentTrades(ctx context.Context, rtr RecentTradesRequest) ([]*MarketTrade, error)
+	// HistoricalTrades returns older trades, it requires API key.
+	HistoricalTrades(ctx context.Context, htr HistoricalTradesRequest) ([]*MarketTrade, error)
 	// Klines returns klines/candlestick data.
 	Klines(ctx context.Context, kr KlinesRequest) ([]*Kline, error)
 	// Ticker24 returns 24hr price change statistics.
-	Ticker24(ctx context.Context, tr TickerRequest) (*Ticker24, error)
+	Ticker24(ctx context.Context, tr TickerRequest) ([]*SymbolTicker24, error)
+	// RollingWindowTicker returns price change statistics within window.
+	RollingWindowTicker(ctx context.Context, rwtr RollingWindowTickerRequest) ([]*SymbolTicker24, error)
 	// TickerAllPrices returns ticker data for symbols.
-	TickerAllPrices(ctx context.Context) ([]*PriceTicker, error)
+	TickerAllPrices(ctx context.Context, tr TickerRequest) ([]*PriceTicker, error)
 	// TickerAllBooks returns tickers for all books.
-	TickerAllBooks(ctx context.Context) ([]*BookTicker, error)
+	TickerAllBooks(ctx context.Context, tr TickerRequest) ([]*BookTicker, error)
 
 	// NewOrder places new order and returns ProcessedOrder.
 	NewOrder(ctx context.Context, nor NewOrderRequest) (*ProcessedOrder, error)
@@ -65,5 +74,9 @@ type Client interface {
 	DepthWebsocket(ctx context.Context, dwr DepthWebsocketRequest) (chan *DepthEvent, chan struct{}, error)
 	KlineWebsocket(ctx context.Context, kwr KlineWebsocketRequest) (chan *KlineEvent, chan struct{}, error)
 	TradeWebsocket(ctx context.Context, twr TradeWebsocketRequest) (chan *AggTradeEvent, chan struct{}, error)
-	UserDataWebsocket(ctx context.Context, udwr UserDataWebsocketRequest) (chan *AccountEvent, chan struct{}, error)
+	RawTradeWebsocket(ctx context.Context, twr TradeWebsocketRequest) (chan *MarketTradeEvent, chan struct{}, error)
+	TickerWebsocket(ctx context.Context, twr TickerWebsocketRequest) (chan *Ticker24Event, chan struct{}, error)
+	MiniTickerWebsocket(ctx context.Context, twr TickerWebsocketRequest) (chan *MiniTickerEvent, chan struct{}, error)
+	BookTickerWebsocket(ctx context.Context, twr TickerWebsocketRequest) (chan *BookTickerEvent, chan struct{}, error)
+	UserDataWebsocket(ctx context.Context, udwr UserDataWebsocketRequest) (chan *UserDataEvent, chan struct{}, error)
 }
@@ -1,6 +1,16 @@
 package binance
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 // Interval represents interval enum.
+//
+// Buckets of intervals are aligned the same way as klines on the exchange:
+// in UTC, from Unix epoch for intervals up to 3d, from Monday for 1w and
+// from the first day of month for 1M.
 type Interval string
 
 var (
@@ -21,10 +31,120 @@ var (
 	Month          = Interval("1M")
 )
 
+// ErrInvalidInterval means string isn't one of supported intervals.
+var ErrInvalidInterval = errors.New("invalid interval")
+
+const week = 7 * 24 * time.Hour
+
+// weekOffset shifts week buckets from Thursday, the weekday of Unix epoch,
+// to Monday.
+const weekOffset = 4 * 24 * time.Hour
+
+// intervalDurations holds lengths of all intervals except Month.
+var intervalDurations = map[Interval]time.Duration{
+	Minute:         time.Minute,
+	ThreeMinutes:   3 * time.Minute,
+	FiveMinutes:    5 * time.Minute,
+	FifteenMinutes: 15 * time.Minute,
+	ThirtyMinutes:  30 * time.Minute,
+	Hour:           time.Hour,
+	TwoHours:       2 * time.Hour,
+	FourHours:      4 * time.Hour,
+	SixHours:       6 * time.Hour,
+	EightHours:     8 * time.Hour,
+	TwelveHours:    12 * time.Hour,
+	Day:            24 * time.Hour,
+	ThreeDays:      3 * 24 * time.Hour,
+	Week:           week,
+}
+
+// ParseInterval returns Interval represented by s like "15m" or "1M".
+func ParseInterval(s string) (Interval, error) {
+	i := Interval(s)
+	if !i.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidInterval, s)
+	}
+	return i, nil
+}
+
+// Valid reports whether i is supported by the exchange.
+func (i Interval) Valid() bool {
+	_, ok := intervalDurations[i]
+	return ok || i == Month
+}
+
+// Duration returns length of i. Month has no fixed length, for it Duration
+// returns 30 days; use BucketDuration for exact length of given month.
+// Invalid interval has zero duration.
+func (i Interval) Duration() time.Duration {
+	if i == Month {
+		return 30 * 24 * time.Hour
+	}
+	return intervalDurations[i]
+}
+
+// BucketDuration returns length of bucket containing t.
+func (i Interval) BucketDuration(t time.Time) time.Duration {
+	start := i.Truncate(t)
+	return i.Next(start).Sub(start)
+}
+
+// Truncate returns start of bucket containing t in UTC.
+func (i Interval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if i == Month {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	d := intervalDurations[i]
+	if d <= 0 {
+		return t
+	}
+	var offset time.Duration
+	if i == Week {
+		offset = weekOffset
+	}
+	sinceEpoch := time.Duration(t.UnixNano()) - offset
+	rem := sinceEpoch % d
+	if rem < 0 {
+		rem += d
+	}
+	return t.Add(-rem)
+}
+
+// Next returns start of bucket following the one containing t.
+func (i Interval) Next(t time.Time) time.Time {
+	start := i.Truncate(t)
+	if i == Month {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.Add(intervalDurations[i])
+}
+
+// Count returns number of buckets starting in [start, end).
+func (i Interval) Count(start, end time.Time) int {
+	first := i.Truncate(start)
+	if first.Before(start) {
+		first = i.Next(first)
+	}
+	if !first.Before(end) {
+		return 0
+	}
+	if i == Month {
+		last := i.Truncate(end.Add(-time.Nanosecond))
+		return (last.Year()-first.Year())*12 + int(last.Month()-first.Month()) + 1
+	}
+	d := intervalDurations[i]
+	if d <= 0 {
+		return 0
+	}
+	return int((end.Sub(first) + d - 1) / d)
+}
+
 // TimeInForce represents timeInForce enum.
 type TimeInForce string
 
 var (
 	GTC = TimeInForce("GTC")
 	IOC = TimeInForce("IOC")
+	FOK = TimeInForce("FOK")
 )
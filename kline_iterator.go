@@ -0,0 +1,162 @@
+package binance
+
+import (
+	"context"
+	"time"
+)
+
+// MaxKlinesLimit is maximum number of klines returned by single request.
+const MaxKlinesLimit = 1000
+
+// KlineRangeRequest represents KlineIterator request data. Klines with open
+// time in [StartTime, EndTime) are returned, zero EndTime means up to now.
+// Limit is page size, zero means MaxKlinesLimit.
+type KlineRangeRequest struct {
+	Symbol    string
+	Interval  Interval
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int
+}
+
+// KlineGap represents missing klines with open time in [Start, End).
+type KlineGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// KlineIterator pages through klines of time range in order. Overlapping
+// page boundaries are de-duplicated and missing klines are reported by Gap:
+// after Next returns true, klines missing right before current one, the
+// first one included when StartTime is set; after Next returns false, klines
+// missing at the end of exhausted range when EndTime is set. Requests go
+// through the client, so they are subject to its rate limiting.
+//
+//	it := binance.NewKlineIterator(client, req)
+//	for it.Next(ctx) {
+//		if gap, ok := it.Gap(); ok {
+//			reportGap(gap)
+//		}
+//		process(it.Kline())
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//	if gap, ok := it.Gap(); ok {
+//		reportGap(gap)
+//	}
+type KlineIterator struct {
+	client  Client
+	request KlineRangeRequest
+
+	cursor time.Time
+	// next is expected open time of the next kline, zero when unknown.
+	next     time.Time
+	page     []*Kline
+	last     *Kline
+	gap      *KlineGap
+	done     bool
+	finished bool
+	err      error
+}
+
+// NewKlineIterator returns iterator over klines of krr.
+func NewKlineIterator(client Client, krr KlineRangeRequest) *KlineIterator {
+	if krr.Limit <= 0 || krr.Limit > MaxKlinesLimit {
+		krr.Limit = MaxKlinesLimit
+	}
+	it := &KlineIterator{
+		client:  client,
+		request: krr,
+		cursor:  krr.StartTime,
+	}
+	if !krr.StartTime.IsZero() {
+		it.next = krr.Interval.Truncate(krr.StartTime)
+		if it.next.Before(krr.StartTime) {
+			it.next = krr.Interval.Next(it.next)
+		}
+	}
+	return it
+}
+
+// Next advances to the next kline, fetching next page when needed. It
+// returns false once range is exhausted or request fails.
+func (it *KlineIterator) Next(ctx context.Context) bool {
+	it.gap = nil
+	for len(it.page) == 0 {
+		if it.err != nil || it.finished {
+			return false
+		}
+		if it.done {
+			it.finished = true
+			it.setGap(it.request.EndTime)
+			return false
+		}
+		it.fetch(ctx)
+	}
+	kline := it.page[0]
+	it.page = it.page[1:]
+	it.setGap(kline.OpenTime)
+	it.last = kline
+	it.next = kline.CloseTime.Add(time.Millisecond)
+	return true
+}
+
+// setGap records klines missing between expected open time and end.
+func (it *KlineIterator) setGap(end time.Time) {
+	if !it.next.IsZero() && end.After(it.next) {
+		it.gap = &KlineGap{Start: it.next, End: end}
+	}
+}
+
+// Kline returns current kline.
+func (it *KlineIterator) Kline() *Kline {
+	return it.last
+}
+
+// Gap returns klines missing right before current one or, once Next
+// returned false, at the end of range.
+func (it *KlineIterator) Gap() (KlineGap, bool) {
+	if it.gap == nil {
+		return KlineGap{}, false
+	}
+	return *it.gap, true
+}
+
+// Err returns error which stopped iteration.
+func (it *KlineIterator) Err() error {
+	return it.err
+}
+
+func (it *KlineIterator) fetch(ctx context.Context) {
+	kr := KlinesRequest{
+		Symbol:    it.request.Symbol,
+		Interval:  it.request.Interval,
+		Limit:     it.request.Limit,
+		StartTime: it.cursor,
+	}
+	if !it.request.EndTime.IsZero() {
+		kr.EndTime = it.request.EndTime.Add(-time.Millisecond)
+	}
+	klines, err := it.client.Klines(ctx, kr)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.done = len(klines) < kr.Limit
+	for _, kline := range klines {
+		if kline.OpenTime.Before(it.cursor) || it.last != nil && !kline.OpenTime.After(it.last.OpenTime) {
+			continue
+		}
+		if !it.request.EndTime.IsZero() && !kline.OpenTime.Before(it.request.EndTime) {
+			it.done = true
+			break
+		}
+		it.page = append(it.page, kline)
+		it.cursor = kline.CloseTime.Add(time.Millisecond)
+	}
+	// Page of already seen klines means there's nothing after them.
+	if len(it.page) == 0 {
+		it.done = true
+	}
+}
@@ -0,0 +1,175 @@
+package binance
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var klineEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func minute(n int) time.Time {
+	return klineEpoch.Add(time.Duration(n) * time.Minute)
+}
+
+// newKlinesClient serves 1m klines opening at given minutes. Every page
+// but the first starts overlap klines before requested start time.
+func newKlinesClient(overlap int, minutes ...int) (*fakeClient, *int) {
+	calls := 0
+	return &fakeClient{klines: func(_ context.Context, kr KlinesRequest) ([]*Kline, error) {
+		calls++
+		if calls > 100 {
+			panic("too many Klines requests")
+		}
+		start := kr.StartTime
+		if calls > 1 {
+			start = start.Add(-time.Duration(overlap) * time.Minute)
+		}
+		var klines []*Kline
+		for _, m := range minutes {
+			openTime := minute(m)
+			if openTime.Before(start) || !kr.EndTime.IsZero() && openTime.After(kr.EndTime) {
+				continue
+			}
+			klines = append(klines, &Kline{OpenTime: openTime, CloseTime: openTime.Add(time.Minute - time.Millisecond)})
+			if len(klines) == kr.Limit {
+				break
+			}
+		}
+		return klines, nil
+	}}, &calls
+}
+
+type iteratedKlines struct {
+	minutes []int
+	// gaps are in minutes, the last one found after Next returned false.
+	gaps [][2]int
+}
+
+func iterateKlines(t *testing.T, it *KlineIterator) iteratedKlines {
+	t.Helper()
+	var got iteratedKlines
+	addGap := func() {
+		if gap, ok := it.Gap(); ok {
+			got.gaps = append(got.gaps, [2]int{int(gap.Start.Sub(klineEpoch) / time.Minute), int(gap.End.Sub(klineEpoch) / time.Minute)})
+		}
+	}
+	for it.Next(context.Background()) {
+		addGap()
+		got.minutes = append(got.minutes, int(it.Kline().OpenTime.Sub(klineEpoch)/time.Minute))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	addGap()
+	if it.Next(context.Background()) {
+		t.Error("Next returned true after range was exhausted")
+	}
+	if _, ok := it.Gap(); ok {
+		t.Error("final gap reported twice")
+	}
+	return got
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKlineIterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		minutes     []int
+		overlap     int
+		start, end  time.Time
+		wantMinutes []int
+		wantGaps    [][2]int
+	}{
+		{
+			name:        "overlapping pages",
+			minutes:     []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			overlap:     2,
+			start:       minute(0),
+			end:         minute(10),
+			wantMinutes: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:        "holes",
+			minutes:     []int{2, 3, 6, 7},
+			overlap:     1,
+			start:       minute(0),
+			end:         minute(10),
+			wantMinutes: []int{2, 3, 6, 7},
+			wantGaps:    [][2]int{{0, 2}, {4, 6}, {8, 10}},
+		},
+		{
+			name:        "hole at page boundary",
+			minutes:     []int{0, 1, 2, 5, 6},
+			start:       minute(0),
+			end:         minute(7),
+			wantMinutes: []int{0, 1, 2, 5, 6},
+			wantGaps:    [][2]int{{3, 5}},
+		},
+		{
+			name:        "unaligned start",
+			minutes:     []int{0, 1, 2, 3},
+			start:       minute(0).Add(30 * time.Second),
+			end:         minute(4),
+			wantMinutes: []int{1, 2, 3},
+		},
+		{
+			name:     "empty range",
+			start:    minute(0).Add(30 * time.Second),
+			end:      minute(5),
+			wantGaps: [][2]int{{1, 5}},
+		},
+		{
+			name:        "no end time",
+			minutes:     []int{0, 2},
+			start:       minute(0),
+			wantMinutes: []int{0, 2},
+			wantGaps:    [][2]int{{1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		client, _ := newKlinesClient(tt.overlap, tt.minutes...)
+		it := NewKlineIterator(client, KlineRangeRequest{
+			Symbol:    "BTCUSDT",
+			Interval:  Minute,
+			StartTime: tt.start,
+			EndTime:   tt.end,
+			Limit:     3,
+		})
+		got := iterateKlines(t, it)
+		if !equalInts(got.minutes, tt.wantMinutes) {
+			t.Errorf("%s: klines %v, want %v", tt.name, got.minutes, tt.wantMinutes)
+		}
+		if len(got.gaps) != len(tt.wantGaps) {
+			t.Errorf("%s: gaps %v, want %v", tt.name, got.gaps, tt.wantGaps)
+			continue
+		}
+		for i := range got.gaps {
+			if got.gaps[i] != tt.wantGaps[i] {
+				t.Errorf("%s: gaps %v, want %v", tt.name, got.gaps, tt.wantGaps)
+				break
+			}
+		}
+	}
+}
+
+func TestKlineIteratorStopsOnSeenPage(t *testing.T) {
+	// Page of already returned klines ends iteration instead of looping.
+	client, calls := newKlinesClient(3, 0, 1, 2)
+	it := NewKlineIterator(client, KlineRangeRequest{Interval: Minute, StartTime: minute(0), Limit: 3})
+	got := iterateKlines(t, it)
+	if !equalInts(got.minutes, []int{0, 1, 2}) || *calls != 2 {
+		t.Errorf("klines %v after %d requests", got.minutes, *calls)
+	}
+}
@@ -0,0 +1,152 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrKlineOutOfBucket means kline doesn't fit into bucket it's aggregated
+// into: it starts before current bucket or ends after it.
+var ErrKlineOutOfBucket = errors.New("kline doesn't fit into bucket")
+
+// Period splits time into consecutive buckets. Interval is Period aligned
+// the same way as exchange klines.
+type Period interface {
+	// Truncate returns start of bucket containing t.
+	Truncate(t time.Time) time.Time
+	// Next returns start of bucket following the one containing t.
+	Next(t time.Time) time.Time
+}
+
+// FixedPeriod is Period of custom length like 7m, aligned to Unix epoch in
+// UTC.
+type FixedPeriod time.Duration
+
+// Truncate returns start of bucket containing t.
+func (p FixedPeriod) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	d := time.Duration(p)
+	if d <= 0 {
+		return t
+	}
+	rem := time.Duration(t.UnixNano()) % d
+	if rem < 0 {
+		rem += d
+	}
+	return t.Add(-rem)
+}
+
+// Next returns start of bucket following the one containing t.
+func (p FixedPeriod) Next(t time.Time) time.Time {
+	return p.Truncate(t).Add(time.Duration(p))
+}
+
+// KlineResampler aggregates closed klines of finer interval into klines of
+// coarser Period. Source interval must divide period, so every source
+// kline fits into single bucket.
+type KlineResampler struct {
+	period Period
+
+	current *Kline
+	end     time.Time
+}
+
+// NewKlineResampler returns resampler into buckets of period.
+func NewKlineResampler(period Period) *KlineResampler {
+	return &KlineResampler{period: period}
+}
+
+// Add merges kline into its bucket and returns buckets completed so far:
+// bucket kline reaches the end of, and previous bucket when kline starts
+// next one without completing it. Klines must be added in order of open
+// time.
+func (r *KlineResampler) Add(kline *Kline) ([]*Kline, error) {
+	var completed []*Kline
+	if r.current != nil && !kline.OpenTime.Before(r.end) {
+		completed = append(completed, r.current)
+		r.current = nil
+	}
+	start, end := r.period.Truncate(kline.OpenTime), r.end
+	if r.current == nil {
+		end = r.period.Next(start)
+	} else {
+		start = r.current.OpenTime
+	}
+	if kline.OpenTime.Before(start) || !kline.CloseTime.Before(end) {
+		return completed, fmt.Errorf("%w: kline %s - %s, bucket %s - %s", ErrKlineOutOfBucket,
+			kline.OpenTime, kline.CloseTime, start, end)
+	}
+	if r.current == nil {
+		r.end = end
+		r.current = &Kline{
+			OpenTime:  start,
+			Open:      kline.Open,
+			High:      kline.High,
+			Low:       kline.Low,
+			CloseTime: end.Add(-time.Millisecond),
+		}
+	}
+	mergeKline(r.current, kline)
+	if !kline.CloseTime.Before(r.current.CloseTime) {
+		completed = append(completed, r.current)
+		r.current = nil
+	}
+	return completed, nil
+}
+
+// Partial returns copy of bucket which didn't complete yet, nil if there's
+// none.
+func (r *KlineResampler) Partial() *Kline {
+	if r.current == nil {
+		return nil
+	}
+	partial := *r.current
+	return &partial
+}
+
+// Flush returns bucket which didn't complete yet and resets resampler.
+func (r *KlineResampler) Flush() *Kline {
+	partial := r.current
+	r.current = nil
+	return partial
+}
+
+// ResampleKlines aggregates klines sorted by open time into buckets of
+// period. Partial first and final buckets, whose source klines don't start
+// at their open time or don't reach their close time, are included only
+// when includePartial is set.
+func ResampleKlines(klines []*Kline, period Period, includePartial bool) ([]*Kline, error) {
+	r := NewKlineResampler(period)
+	var resampled []*Kline
+	for _, kline := range klines {
+		completed, err := r.Add(kline)
+		if err != nil {
+			return nil, err
+		}
+		resampled = append(resampled, completed...)
+	}
+	if partial := r.Flush(); partial != nil && includePartial {
+		resampled = append(resampled, partial)
+	}
+	if !includePartial && len(resampled) > 0 && !resampled[0].OpenTime.Equal(klines[0].OpenTime) {
+		resampled = resampled[1:]
+	}
+	return resampled, nil
+}
+
+// mergeKline adds kline following dst in time to dst.
+func mergeKline(dst, kline *Kline) {
+	if kline.High.GreaterThan(dst.High) {
+		dst.High = kline.High
+	}
+	if kline.Low.LessThan(dst.Low) {
+		dst.Low = kline.Low
+	}
+	dst.Close = kline.Close
+	dst.Volume = dst.Volume.Add(kline.Volume)
+	dst.QuoteAssetVolume = dst.QuoteAssetVolume.Add(kline.QuoteAssetVolume)
+	dst.NumberOfTrades += kline.NumberOfTrades
+	dst.TakerBuyBaseAssetVolume = dst.TakerBuyBaseAssetVolume.Add(kline.TakerBuyBaseAssetVolume)
+	dst.TakerBuyQuoteAssetVolume = dst.TakerBuyQuoteAssetVolume.Add(kline.TakerBuyQuoteAssetVolume)
+}
@@ -0,0 +1,349 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLocalOrderBookLimit is depth of snapshot LocalOrderBook starts
+	// from.
+	DefaultLocalOrderBookLimit = 1000
+
+	localOrderBookRetryDelay = time.Second
+	// maxPendingDepthEvents bounds diffs buffered while book is unsynced,
+	// the oldest are dropped first.
+	maxPendingDepthEvents = 1000
+)
+
+// ErrDepthGap means depth diff doesn't continue previously applied one.
+var ErrDepthGap = errors.New("gap in depth updates")
+
+// LocalOrderBook maintains order book of single symbol by applying
+// DepthWebsocket diffs on top of OrderBook snapshot. Diffs are sequenced by
+// exchange rules: diffs ending before snapshot are dropped, the first
+// applied diff must contain update following snapshot and every next diff
+// must start right after the previous one. Gap or lost connection makes
+// book unsynced until new snapshot is loaded, which happens automatically.
+// Diffs received meanwhile are buffered and applied once snapshot they
+// continue is loaded. Snapshots are fetched at most once a second, failed or
+// stale ones included.
+//
+// Query methods are safe for concurrent use and report false while book is
+// not synced.
+type LocalOrderBook struct {
+	client  Client
+	request DepthWebsocketRequest
+	limit   int
+	// retryDelay is minimal delay between snapshots and reconnects.
+	retryDelay time.Duration
+
+	// Fields below up to mu are used only by run goroutine.
+	// pending are diffs received while unsynced.
+	pending []*DepthEvent
+	// loaded means snapshot is loaded and pending diffs wait for the one
+	// following it.
+	loaded       bool
+	nextSnapshot time.Time
+
+	mu           sync.RWMutex
+	synced       bool
+	lastUpdateID int64
+	updateTime   time.Time
+	// bids are sorted by price descending, asks ascending.
+	bids []*Order
+	asks []*Order
+	err  error
+}
+
+// NewLocalOrderBook returns book of dwr.Symbol synchronized from snapshots
+// of given limit. Non-positive limit means DefaultLocalOrderBookLimit.
+func NewLocalOrderBook(client Client, dwr DepthWebsocketRequest, limit int) *LocalOrderBook {
+	if limit <= 0 {
+		limit = DefaultLocalOrderBookLimit
+	}
+	return &LocalOrderBook{
+		client:     client,
+		request:    dwr,
+		limit:      limit,
+		retryDelay: localOrderBookRetryDelay,
+	}
+}
+
+// Start subscribes to depth diffs and keeps book synced until ctx is done.
+// Returned channel is closed once book stops.
+func (b *LocalOrderBook) Start(ctx context.Context) (chan struct{}, error) {
+	events, _, err := b.client.DepthWebsocket(ctx, b.request)
+	if err != nil {
+		return nil, err
+	}
+	doneC := make(chan struct{})
+	go b.run(ctx, events, doneC)
+	return doneC, nil
+}
+
+func (b *LocalOrderBook) run(ctx context.Context, events chan *DepthEvent, doneC chan struct{}) {
+	defer close(doneC)
+	for {
+		for event := range events {
+			b.handle(ctx, event)
+		}
+		b.desync(nil)
+		for {
+			timer := time.NewTimer(b.retryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			var err error
+			events, _, err = b.client.DepthWebsocket(ctx, b.request)
+			if err == nil {
+				break
+			}
+			b.setErr(err)
+		}
+	}
+}
+
+// handle applies event or buffers it until snapshot it continues is
+// loaded. Only run goroutine modifies book, so it reads sync state without
+// lock.
+func (b *LocalOrderBook) handle(ctx context.Context, event *DepthEvent) {
+	if b.synced {
+		if event.FirstUpdateID == b.lastUpdateID+1 {
+			b.apply(event)
+			return
+		}
+		b.desync(fmt.Errorf("%w: expected update %d, got %d", ErrDepthGap, b.lastUpdateID+1, event.FirstUpdateID))
+	}
+	if len(b.pending) == maxPendingDepthEvents {
+		b.pending = append(b.pending[:0], b.pending[1:]...)
+	}
+	b.pending = append(b.pending, event)
+	if !b.loaded {
+		if time.Now().Before(b.nextSnapshot) {
+			return
+		}
+		b.nextSnapshot = time.Now().Add(b.retryDelay)
+		if err := b.loadSnapshot(ctx); err != nil {
+			b.setErr(err)
+			return
+		}
+		b.loaded = true
+	}
+	b.loaded = b.applyPending()
+}
+
+// applyPending drops pending diffs preceding snapshot and applies the rest
+// once the first of them contains update following snapshot. It returns
+// false when snapshot is older than pending diffs or they have gap, so newer
+// snapshot is needed.
+func (b *LocalOrderBook) applyPending() bool {
+	i := 0
+	for i < len(b.pending) && b.pending[i].LastUpdateID <= b.lastUpdateID {
+		i++
+	}
+	b.pending = append(b.pending[:0], b.pending[i:]...)
+	if len(b.pending) == 0 {
+		return true
+	}
+	if b.pending[0].FirstUpdateID > b.lastUpdateID+1 {
+		return false
+	}
+	for i, event := range b.pending {
+		if i > 0 && event.FirstUpdateID != b.lastUpdateID+1 {
+			pending := append(b.pending[:0], b.pending[i:]...)
+			b.desync(fmt.Errorf("%w: expected update %d, got %d", ErrDepthGap, b.lastUpdateID+1, event.FirstUpdateID))
+			b.pending = pending
+			return false
+		}
+		b.apply(event)
+	}
+	b.pending = b.pending[:0]
+	return true
+}
+
+func (b *LocalOrderBook) loadSnapshot(ctx context.Context) error {
+	ob, err := b.client.OrderBook(ctx, OrderBookRequest{Symbol: b.request.Symbol, Limit: b.limit})
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastUpdateID = ob.LastUpdateID
+	b.bids = b.bids[:0]
+	for _, bid := range ob.Bids {
+		b.bids = setLevel(b.bids, bid, true)
+	}
+	b.asks = b.asks[:0]
+	for _, ask := range ob.Asks {
+		b.asks = setLevel(b.asks, ask, false)
+	}
+	return nil
+}
+
+func (b *LocalOrderBook) apply(event *DepthEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, bid := range event.Bids {
+		b.bids = setLevel(b.bids, bid, true)
+	}
+	for _, ask := range event.Asks {
+		b.asks = setLevel(b.asks, ask, false)
+	}
+	b.lastUpdateID = event.LastUpdateID
+	b.updateTime = event.Time
+	b.synced = true
+	b.err = nil
+}
+
+// desync marks book unsynced, so next event loads new snapshot.
+func (b *LocalOrderBook) desync(err error) {
+	b.pending = b.pending[:0]
+	b.loaded = false
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.synced = false
+	b.lastUpdateID = 0
+	if err != nil {
+		b.err = err
+	}
+}
+
+func (b *LocalOrderBook) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+// setLevel replaces quantity of level's price in sorted levels, removing it
+// when quantity is zero.
+func setLevel(levels []*Order, level *Order, descending bool) []*Order {
+	i := sort.Search(len(levels), func(i int) bool {
+		if descending {
+			return !levels[i].Price.GreaterThan(level.Price)
+		}
+		return !levels[i].Price.LessThan(level.Price)
+	})
+	found := i < len(levels) && levels[i].Price.Equal(level.Price)
+	switch {
+	case found && level.Quantity.IsZero():
+		return append(levels[:i], levels[i+1:]...)
+	case found:
+		levels[i] = &Order{Price: level.Price, Quantity: level.Quantity}
+	case !level.Quantity.IsZero():
+		levels = append(levels, nil)
+		copy(levels[i+1:], levels[i:])
+		levels[i] = &Order{Price: level.Price, Quantity: level.Quantity}
+	}
+	return levels
+}
+
+// Synced reports whether book reflects the latest applied diff.
+func (b *LocalOrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Err returns the last error which prevented book from being synced, nil
+// once it's synced again.
+func (b *LocalOrderBook) Err() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.err
+}
+
+// UpdateTime returns event time of the last applied diff.
+func (b *LocalOrderBook) UpdateTime() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updateTime
+}
+
+// BestBid returns the highest bid.
+func (b *LocalOrderBook) BestBid() (Order, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.bids) == 0 {
+		return Order{}, false
+	}
+	return *b.bids[0], true
+}
+
+// BestAsk returns the lowest ask.
+func (b *LocalOrderBook) BestAsk() (Order, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.asks) == 0 {
+		return Order{}, false
+	}
+	return *b.asks[0], true
+}
+
+// Depth returns copy of top levels of both sides. Non-positive levels means
+// the whole book.
+func (b *LocalOrderBook) Depth(levels int) (*OrderBook, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return nil, false
+	}
+	return &OrderBook{
+		LastUpdateID: b.lastUpdateID,
+		Bids:         copyLevels(b.bids, levels),
+		Asks:         copyLevels(b.asks, levels),
+	}, true
+}
+
+// CumulativeBidVolume returns total quantity of bids at price or higher.
+func (b *LocalOrderBook) CumulativeBidVolume(price Decimal) (Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return Decimal{}, false
+	}
+	var volume Decimal
+	for _, bid := range b.bids {
+		if bid.Price.LessThan(price) {
+			break
+		}
+		volume = volume.Add(bid.Quantity)
+	}
+	return volume, true
+}
+
+// CumulativeAskVolume returns total quantity of asks at price or lower.
+func (b *LocalOrderBook) CumulativeAskVolume(price Decimal) (Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return Decimal{}, false
+	}
+	var volume Decimal
+	for _, ask := range b.asks {
+		if ask.Price.GreaterThan(price) {
+			break
+		}
+		volume = volume.Add(ask.Quantity)
+	}
+	return volume, true
+}
+
+func copyLevels(levels []*Order, n int) []*Order {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	copied := make([]*Order, n)
+	for i := range copied {
+		level := *levels[i]
+		copied[i] = &level
+	}
+	return copied
+}
@@ -0,0 +1,206 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func level(price, quantity string) *Order {
+	return &Order{Price: MustParseDecimal(price), Quantity: MustParseDecimal(quantity)}
+}
+
+func depthEvent(first, last int64, bids ...*Order) *DepthEvent {
+	return &DepthEvent{
+		FirstUpdateID: first,
+		OrderBook:     OrderBook{LastUpdateID: last, Bids: bids},
+	}
+}
+
+// snapshotClient serves snapshots in order, counting requests.
+type snapshotClient struct {
+	fakeClient
+	snapshots []*OrderBook
+	calls     int
+}
+
+func newSnapshotClient(snapshots ...*OrderBook) *snapshotClient {
+	c := &snapshotClient{snapshots: snapshots}
+	c.orderBook = func(context.Context, OrderBookRequest) (*OrderBook, error) {
+		ob := c.snapshots[c.calls]
+		c.calls++
+		if ob == nil {
+			return nil, errors.New("snapshot failed")
+		}
+		return ob, nil
+	}
+	return c
+}
+
+// newTestLocalOrderBook returns book whose snapshots are throttled until
+// allowSnapshot is called, events are handled by calling handle directly.
+func newTestLocalOrderBook(client Client) *LocalOrderBook {
+	b := NewLocalOrderBook(client, DepthWebsocketRequest{Symbol: "BTCUSDT"}, 0)
+	b.retryDelay = time.Hour
+	return b
+}
+
+func allowSnapshot(b *LocalOrderBook) {
+	b.nextSnapshot = time.Time{}
+}
+
+func TestLocalOrderBookSyncBoundary(t *testing.T) {
+	tests := []struct {
+		name           string
+		events         []*DepthEvent
+		wantSynced     bool
+		wantLastUpdate int64
+	}{
+		{"first update follows snapshot", []*DepthEvent{depthEvent(101, 101)}, true, 101},
+		{"first update inside diff", []*DepthEvent{depthEvent(98, 102)}, true, 102},
+		{"older diffs dropped", []*DepthEvent{depthEvent(90, 95), depthEvent(96, 100), depthEvent(101, 103)}, true, 103},
+		{"diff ending at snapshot", []*DepthEvent{depthEvent(96, 100)}, false, 100},
+		{"diff after gap", []*DepthEvent{depthEvent(102, 103)}, false, 100},
+		{"older diff then gap", []*DepthEvent{depthEvent(96, 100), depthEvent(102, 103)}, false, 100},
+	}
+	for _, tt := range tests {
+		client := newSnapshotClient(&OrderBook{LastUpdateID: 100, Bids: []*Order{level("10", "1")}})
+		b := newTestLocalOrderBook(client)
+		for _, event := range tt.events {
+			b.handle(context.Background(), event)
+		}
+		if b.Synced() != tt.wantSynced || b.lastUpdateID != tt.wantLastUpdate {
+			t.Errorf("%s: synced %v at %d, want %v at %d", tt.name, b.Synced(), b.lastUpdateID, tt.wantSynced, tt.wantLastUpdate)
+		}
+		if client.calls != 1 {
+			t.Errorf("%s: %d snapshots loaded, want 1", tt.name, client.calls)
+		}
+	}
+}
+
+func TestLocalOrderBookSnapshotNewerThanDiffs(t *testing.T) {
+	client := newSnapshotClient(&OrderBook{LastUpdateID: 100, Bids: []*Order{level("10", "1")}})
+	b := newTestLocalOrderBook(client)
+	ctx := context.Background()
+	b.handle(ctx, depthEvent(91, 95))
+	if b.Synced() {
+		t.Fatal("synced without diff following snapshot")
+	}
+	// Loaded snapshot waits for diff following it without fetching another.
+	b.handle(ctx, depthEvent(96, 100))
+	b.handle(ctx, depthEvent(101, 102, level("10", "0"), level("9", "2")))
+	if !b.Synced() || client.calls != 1 {
+		t.Fatalf("synced %v after %d snapshots", b.Synced(), client.calls)
+	}
+	if bid, ok := b.BestBid(); !ok || bid.Price.String() != "9" || bid.Quantity.String() != "2" {
+		t.Errorf("best bid = %+v, %v", bid, ok)
+	}
+}
+
+func TestLocalOrderBookStaleSnapshot(t *testing.T) {
+	client := newSnapshotClient(
+		&OrderBook{LastUpdateID: 5, Bids: []*Order{level("10", "1")}},
+		nil,
+		&OrderBook{LastUpdateID: 13, Bids: []*Order{level("10", "3"), level("9", "1")}},
+	)
+	b := newTestLocalOrderBook(client)
+	ctx := context.Background()
+
+	b.handle(ctx, depthEvent(10, 12, level("10", "2")))
+	if b.Synced() || client.calls != 1 {
+		t.Fatalf("synced %v after %d snapshots", b.Synced(), client.calls)
+	}
+	// Stale snapshot isn't fetched again before retry delay.
+	b.handle(ctx, depthEvent(13, 14, level("9", "5")))
+	if client.calls != 1 {
+		t.Fatalf("%d snapshots fetched within retry delay", client.calls)
+	}
+
+	// Neither is failed one.
+	allowSnapshot(b)
+	b.handle(ctx, depthEvent(15, 15, level("8", "1")))
+	if b.Synced() || client.calls != 2 || b.Err() == nil {
+		t.Fatalf("synced %v after %d snapshots, error %v", b.Synced(), client.calls, b.Err())
+	}
+	b.handle(ctx, depthEvent(16, 16))
+	if client.calls != 2 {
+		t.Fatalf("%d snapshots fetched within retry delay", client.calls)
+	}
+
+	// Buffered diffs continuing the snapshot are applied.
+	allowSnapshot(b)
+	b.handle(ctx, depthEvent(17, 17, level("7", "1")))
+	if !b.Synced() || client.calls != 3 || b.Err() != nil {
+		t.Fatalf("synced %v after %d snapshots, error %v", b.Synced(), client.calls, b.Err())
+	}
+	depth, _ := b.Depth(0)
+	want := []string{"10:3", "9:5", "8:1", "7:1"}
+	if depth.LastUpdateID != 17 || len(depth.Bids) != len(want) {
+		t.Fatalf("depth = %d %v", depth.LastUpdateID, depth.Bids)
+	}
+	for i, bid := range depth.Bids {
+		if got := bid.Price.String() + ":" + bid.Quantity.String(); got != want[i] {
+			t.Errorf("bid %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestLocalOrderBookGapResync(t *testing.T) {
+	client := newSnapshotClient(
+		&OrderBook{LastUpdateID: 100, Bids: []*Order{level("10", "1")}},
+		&OrderBook{LastUpdateID: 110, Bids: []*Order{level("11", "1")}},
+	)
+	b := newTestLocalOrderBook(client)
+	ctx := context.Background()
+	b.handle(ctx, depthEvent(101, 102))
+	b.handle(ctx, depthEvent(103, 103))
+	if !b.Synced() {
+		t.Fatal("not synced")
+	}
+
+	b.handle(ctx, depthEvent(105, 106))
+	if b.Synced() || !errors.Is(b.Err(), ErrDepthGap) {
+		t.Fatalf("synced %v after gap, error %v", b.Synced(), b.Err())
+	}
+	if _, ok := b.BestBid(); ok {
+		t.Error("unsynced book returned best bid")
+	}
+	b.handle(ctx, depthEvent(107, 109))
+	if client.calls != 1 {
+		t.Fatalf("%d snapshots fetched within retry delay", client.calls)
+	}
+
+	allowSnapshot(b)
+	b.handle(ctx, depthEvent(110, 111, level("12", "2")))
+	b.handle(ctx, depthEvent(112, 112))
+	if !b.Synced() || b.lastUpdateID != 112 || client.calls != 2 || b.Err() != nil {
+		t.Fatalf("synced %v at %d after %d snapshots, error %v", b.Synced(), b.lastUpdateID, client.calls, b.Err())
+	}
+	if bid, _ := b.BestBid(); bid.Price.String() != "12" {
+		t.Errorf("best bid = %+v", bid)
+	}
+}
+
+func TestLocalOrderBookPendingGap(t *testing.T) {
+	client := newSnapshotClient(
+		&OrderBook{LastUpdateID: 50},
+		&OrderBook{LastUpdateID: 100},
+		&OrderBook{LastUpdateID: 104},
+	)
+	b := newTestLocalOrderBook(client)
+	ctx := context.Background()
+	b.handle(ctx, depthEvent(96, 102))
+	b.handle(ctx, depthEvent(104, 105))
+	allowSnapshot(b)
+	b.handle(ctx, depthEvent(106, 106))
+	if b.Synced() || !errors.Is(b.Err(), ErrDepthGap) {
+		t.Fatalf("synced %v with gap in buffered diffs, error %v", b.Synced(), b.Err())
+	}
+	// Diffs after the gap stay buffered for the next snapshot.
+	allowSnapshot(b)
+	b.handle(ctx, depthEvent(107, 107))
+	if !b.Synced() || b.lastUpdateID != 107 || client.calls != 3 {
+		t.Fatalf("synced %v at %d after %d snapshots", b.Synced(), b.lastUpdateID, client.calls)
+	}
+}
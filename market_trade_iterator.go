@@ -0,0 +1,97 @@
+package binance
+
+import (
+	"context"
+)
+
+// MaxTradesLimit is maximum number of trades returned by single
+// RecentTrades or HistoricalTrades request.
+const MaxTradesLimit = 1000
+
+// MarketTradeRangeRequest represents MarketTradeIterator request data.
+// Trades starting with FromID are returned up to the latest one, nil FromID
+// starts from the most recent trades. Limit is page size, zero means
+// MaxTradesLimit.
+type MarketTradeRangeRequest struct {
+	Symbol string
+	FromID *int64
+	Limit  int
+}
+
+// MarketTradeIterator walks individual trades forward, paging them by ID
+// with HistoricalTrades. Requests go through the client, so they are
+// subject to its rate limiting.
+type MarketTradeIterator struct {
+	client  Client
+	request MarketTradeRangeRequest
+
+	// fromID is ID of the next trade, nil until the first page is fetched
+	// when request has no FromID.
+	fromID *int64
+	page   []*MarketTrade
+	trade  *MarketTrade
+	done   bool
+	err    error
+}
+
+// NewMarketTradeIterator returns iterator over trades of mtrr.
+func NewMarketTradeIterator(client Client, mtrr MarketTradeRangeRequest) *MarketTradeIterator {
+	if mtrr.Limit <= 0 || mtrr.Limit > MaxTradesLimit {
+		mtrr.Limit = MaxTradesLimit
+	}
+	return &MarketTradeIterator{
+		client:  client,
+		request: mtrr,
+		fromID:  mtrr.FromID,
+	}
+}
+
+// Next advances to the next trade, fetching next page when needed. It
+// returns false once the latest trade is passed or request fails.
+func (it *MarketTradeIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch(ctx)
+	}
+	it.trade = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// MarketTrade returns current trade.
+func (it *MarketTradeIterator) MarketTrade() *MarketTrade {
+	return it.trade
+}
+
+// Err returns error which stopped iteration.
+func (it *MarketTradeIterator) Err() error {
+	return it.err
+}
+
+func (it *MarketTradeIterator) fetch(ctx context.Context) {
+	trades, err := it.client.HistoricalTrades(ctx, HistoricalTradesRequest{
+		Symbol: it.request.Symbol,
+		FromID: it.fromID,
+		Limit:  it.request.Limit,
+	})
+	if err != nil {
+		it.err = err
+		return
+	}
+	if len(trades) < it.request.Limit {
+		it.done = true
+	}
+	for _, trade := range trades {
+		if it.fromID != nil && trade.ID < *it.fromID {
+			continue
+		}
+		it.page = append(it.page, trade)
+		next := trade.ID + 1
+		it.fromID = &next
+	}
+	if len(it.page) == 0 {
+		it.done = true
+	}
+}
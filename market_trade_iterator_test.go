@@ -0,0 +1,97 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// newTradesClient serves trades with IDs from 0 to last. Every page starts
+// overlap trades before requested one. Requested FromIDs are recorded,
+// -1 standing for nil.
+func newTradesClient(last int64, overlap int64) (*fakeClient, *[]int64) {
+	var requested []int64
+	return &fakeClient{historicalTrades: func(_ context.Context, htr HistoricalTradesRequest) ([]*MarketTrade, error) {
+		if len(requested) > 100 {
+			panic("too many HistoricalTrades requests")
+		}
+		from := last - int64(htr.Limit) + 1
+		if htr.FromID == nil {
+			requested = append(requested, -1)
+		} else {
+			requested = append(requested, *htr.FromID)
+			from = *htr.FromID - overlap
+		}
+		if from < 0 {
+			from = 0
+		}
+		var trades []*MarketTrade
+		for id := from; id <= last && len(trades) < htr.Limit; id++ {
+			trades = append(trades, &MarketTrade{ID: id})
+		}
+		return trades, nil
+	}}, &requested
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestMarketTradeIterator(t *testing.T) {
+	tests := []struct {
+		name          string
+		fromID        *int64
+		overlap       int64
+		wantFirst     int64
+		wantRequested []int64
+	}{
+		{"from the first trade", int64Ptr(0), 0, 0, []int64{0, 10, 20}},
+		{"from trade", int64Ptr(7), 0, 7, []int64{7, 17}},
+		{"overlapping pages", int64Ptr(0), 3, 0, []int64{0, 10, 17, 24}},
+		{"from the latest trades", nil, 0, 16, []int64{-1, 26}},
+	}
+	for _, tt := range tests {
+		client, requested := newTradesClient(25, tt.overlap)
+		it := NewMarketTradeIterator(client, MarketTradeRangeRequest{Symbol: "BTCUSDT", FromID: tt.fromID, Limit: 10})
+		want := tt.wantFirst
+		for it.Next(context.Background()) {
+			if got := it.MarketTrade().ID; got != want {
+				t.Fatalf("%s: trade %d, want %d", tt.name, got, want)
+			}
+			want++
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if want != 26 {
+			t.Errorf("%s: iteration stopped before trade %d", tt.name, want)
+		}
+		if fmt.Sprint(*requested) != fmt.Sprint(tt.wantRequested) {
+			t.Errorf("%s: requested from %v, want %v", tt.name, *requested, tt.wantRequested)
+		}
+	}
+}
+
+func TestMarketTradeIteratorError(t *testing.T) {
+	errFailed := errors.New("failed")
+	calls := 0
+	client := &fakeClient{historicalTrades: func(context.Context, HistoricalTradesRequest) ([]*MarketTrade, error) {
+		calls++
+		if calls > 1 {
+			return nil, errFailed
+		}
+		return []*MarketTrade{{ID: 0}, {ID: 1}}, nil
+	}}
+	it := NewMarketTradeIterator(client, MarketTradeRangeRequest{FromID: int64Ptr(0), Limit: 2})
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	if n != 2 || !errors.Is(it.Err(), errFailed) {
+		t.Errorf("%d trades, error %v", n, it.Err())
+	}
+	if it.Next(context.Background()) || calls != 2 {
+		t.Errorf("iteration went on after error, %d requests", calls)
+	}
+}
@@ -1,21 +1,9 @@
 package binance
 
 import (
-	"fmt"
 	"time"
 )
 
-// Error represents Client error structure with error code and message.
-type Error struct {
-	Code    int    `json:"code"`
-	Message string `json:"msg"`
-}
-
-// Error returns formatted error message.
-func (e Error) Error() string {
-	return fmt.Sprintf("%d: %s", e.Code, e.Message)
-}
-
 // OrderBook represents Bids and Asks.
 type OrderBook struct {
 	LastUpdateID int64 `json:"lastUpdateId"`
@@ -23,16 +11,19 @@ type OrderBook struct {
 	Asks         []*Order
 }
 
+// DepthEvent represents order book diff. Embedded OrderBook carries changed
+// price levels, zero quantity meaning the level was removed, and its
+// LastUpdateID is ID of the final update in event.
 type DepthEvent struct {
 	WSEvent
-	UpdateID int
+	FirstUpdateID int64
 	OrderBook
 }
 
 // Order represents single order information.
 type Order struct {
-	Price    float64
-	Quantity float64
+	Price    Decimal
+	Quantity Decimal
 }
 
 // OrderBookRequest represents OrderBook request data.
@@ -44,8 +35,8 @@ type OrderBookRequest struct {
 // AggTrade represents aggregated trade.
 type AggTrade struct {
 	ID             int
-	Price          float64
-	Quantity       float64
+	Price          Decimal
+	Quantity       Decimal
 	FirstTradeID   int
 	LastTradeID    int
 	Timestamp      time.Time
@@ -58,7 +49,43 @@ type AggTradeEvent struct {
 	AggTrade
 }
 
-// AggTradesRequest represents AggTrades request data.
+// MarketTrade represents individual trade of symbol.
+type MarketTrade struct {
+	ID             int64
+	Price          Decimal
+	Quantity       Decimal
+	QuoteQuantity  Decimal
+	Timestamp      time.Time
+	BuyerMaker     bool
+	BestPriceMatch bool
+}
+
+// MarketTradeEvent represents individual trade pushed by websocket. Its
+// QuoteQuantity and BestPriceMatch aren't set.
+type MarketTradeEvent struct {
+	WSEvent
+	BuyerOrderID  int64
+	SellerOrderID int64
+	MarketTrade
+}
+
+// RecentTradesRequest represents RecentTrades request data.
+type RecentTradesRequest struct {
+	Symbol string
+	Limit  int
+}
+
+// HistoricalTradesRequest represents HistoricalTrades request data. Nil
+// FromID means the most recent trades; trade IDs start with zero, so zero
+// FromID requests the first trade of symbol.
+type HistoricalTradesRequest struct {
+	Symbol string
+	FromID *int64
+	Limit  int
+}
+
+// AggTradesRequest represents AggTrades request data. StartTime and EndTime
+// must be less than an hour apart when both are set.
 type AggTradesRequest struct {
 	Symbol    string
 	FromID    int64
@@ -67,28 +94,29 @@ type AggTradesRequest struct {
 	Limit     int
 }
 
-// KlinesRequest represents Klines request data.
+// KlinesRequest represents Klines request data. StartTime and EndTime
+// bound open times of returned klines, both inclusive; zero means unbounded.
 type KlinesRequest struct {
 	Symbol    string
 	Interval  Interval
 	Limit     int
 	StartTime time.Time
-	EndTime   int64
+	EndTime   time.Time
 }
 
 // Kline represents single Kline information.
 type Kline struct {
 	OpenTime                 time.Time
-	Open                     float64
-	High                     float64
-	Low                      float64
-	Close                    float64
-	Volume                   float64
+	Open                     Decimal
+	High                     Decimal
+	Low                      Decimal
+	Close                    Decimal
+	Volume                   Decimal
 	CloseTime                time.Time
-	QuoteAssetVolume         float64
+	QuoteAssetVolume         Decimal
 	NumberOfTrades           int
-	TakerBuyBaseAssetVolume  float64
-	TakerBuyQuoteAssetVolume float64
+	TakerBuyBaseAssetVolume  Decimal
+	TakerBuyQuoteAssetVolume Decimal
 }
 
 type KlineEvent struct {
@@ -100,24 +128,38 @@ type KlineEvent struct {
 	Kline
 }
 
-// TickerRequest represents Ticker request data.
+// TickerRequest represents Ticker24, TickerAllPrices and TickerAllBooks
+// request data. Symbols requests several symbols at once, with Symbol and
+// Symbols both empty all symbols are requested.
 type TickerRequest struct {
-	Symbol string
+	Symbol  string
+	Symbols []string
+}
+
+// RollingWindowTickerRequest represents RollingWindowTicker request data.
+// At least one symbol is required, ErrNoSymbol is returned otherwise.
+// WindowSize is checked by FormatWindowSize, zero means 1 day.
+type RollingWindowTickerRequest struct {
+	Symbol     string
+	Symbols    []string
+	WindowSize time.Duration
 }
 
-// Ticker24 represents data for 24hr ticker.
+// Ticker24 represents data for 24hr ticker. Rolling window ticker has no
+// PrevClosePrice, BidPrice and AskPrice.
 type Ticker24 struct {
-	PriceChange        float64
-	PriceChangePercent float64
-	WeightedAvgPrice   float64
-	PrevClosePrice     float64
-	LastPrice          float64
-	BidPrice           float64
-	AskPrice           float64
-	OpenPrice          float64
-	HighPrice          float64
-	LowPrice           float64
-	Volume             float64
+	PriceChange        Decimal
+	PriceChangePercent Decimal
+	WeightedAvgPrice   Decimal
+	PrevClosePrice     Decimal
+	LastPrice          Decimal
+	BidPrice           Decimal
+	AskPrice           Decimal
+	OpenPrice          Decimal
+	HighPrice          Decimal
+	LowPrice           Decimal
+	Volume             Decimal
+	QuoteVolume        Decimal
 	OpenTime           time.Time
 	CloseTime          time.Time
 	FirstID            int
@@ -125,41 +167,101 @@ type Ticker24 struct {
 	Count              int
 }
 
+// SymbolTicker24 represents Ticker24 of symbol returned by Ticker24 and
+// RollingWindowTicker.
+type SymbolTicker24 struct {
+	Symbol string
+	Ticker24
+}
+
+// Ticker24Event represents 24hr ticker pushed by websocket.
+type Ticker24Event struct {
+	WSEvent
+	Ticker24
+}
+
+// MiniTickerEvent represents 24hr mini ticker pushed by websocket. Only
+// LastPrice, OpenPrice, HighPrice, LowPrice, Volume and QuoteVolume of
+// Ticker24 are set.
+type MiniTickerEvent struct {
+	WSEvent
+	Ticker24
+}
+
 // PriceTicker represents ticker data for price.
 type PriceTicker struct {
 	Symbol string
-	Price  float64
+	Price  Decimal
 }
 
 // BookTicker represents book ticker data.
 type BookTicker struct {
 	Symbol   string
-	BidPrice float64
-	BidQty   float64
-	AskPrice float64
-	AskQty   float64
+	BidPrice Decimal
+	BidQty   Decimal
+	AskPrice Decimal
+	AskQty   Decimal
+}
+
+// BookTickerEvent represents best price and quantity update pushed by
+// websocket. Exchange sends neither event type nor time, so Type is always
+// "bookTicker" and Time is zero. Symbol is set in both WSEvent and
+// BookTicker, so it has to be selected through one of them.
+type BookTickerEvent struct {
+	WSEvent
+	UpdateID int64
+	BookTicker
 }
 
 // NewOrderRequest represents NewOrder request data.
+//
+// QuoteOrderQty replaces Quantity of market orders. TrailingDelta, in basis
+// points, makes stop and take profit orders trailing. Empty
+// NewOrderRespType means exchange default, FULL for market and limit
+// orders and ACK for others. Fields required by Type are checked by
+// CheckOrderRequest before order is sent.
 type NewOrderRequest struct {
 	Symbol           string
 	Side             OrderSide
 	Type             OrderType
 	TimeInForce      TimeInForce
-	Quantity         float64
-	Price            float64
+	Quantity         Decimal
+	QuoteOrderQty    Decimal
+	Price            Decimal
 	NewClientOrderID string
-	StopPrice        float64
-	IcebergQty       float64
+	StopPrice        Decimal
+	TrailingDelta    int
+	IcebergQty       Decimal
+	NewOrderRespType OrderResponseType
+	RecvWindow       time.Duration
 	Timestamp        time.Time
 }
 
-// ProcessedOrder represents data from processed order.
+// ProcessedOrder represents data from processed order. Fields following
+// TransactTime are set by RESULT and FULL responses, Fills only by FULL.
 type ProcessedOrder struct {
-	Symbol        string
-	OrderID       int64
-	ClientOrderID string
-	TransactTime  time.Time
+	Symbol              string
+	OrderID             int64
+	ClientOrderID       string
+	TransactTime        time.Time
+	Price               Decimal
+	OrigQty             Decimal
+	ExecutedQty         Decimal
+	CummulativeQuoteQty Decimal
+	Status              OrderStatus
+	TimeInForce         TimeInForce
+	Type                OrderType
+	Side                OrderSide
+	Fills               []*Fill
+}
+
+// Fill represents partial execution of processed order.
+type Fill struct {
+	TradeID         int64
+	Price           Decimal
+	Qty             Decimal
+	Commission      Decimal
+	CommissionAsset string
 }
 
 // QueryOrderRequest represents QueryOrder request data.
@@ -173,19 +275,20 @@ type QueryOrderRequest struct {
 
 // ExecutedOrder represents data about executed order.
 type ExecutedOrder struct {
-	Symbol        string
-	OrderID       int
-	ClientOrderID string
-	Price         float64
-	OrigQty       float64
-	ExecutedQty   float64
-	Status        OrderStatus
-	TimeInForce   TimeInForce
-	Type          OrderType
-	Side          OrderSide
-	StopPrice     float64
-	IcebergQty    float64
-	Time          time.Time
+	Symbol              string
+	OrderID             int
+	ClientOrderID       string
+	Price               Decimal
+	OrigQty             Decimal
+	ExecutedQty         Decimal
+	CummulativeQuoteQty Decimal
+	Status              OrderStatus
+	TimeInForce         TimeInForce
+	Type                OrderType
+	Side                OrderSide
+	StopPrice           Decimal
+	IcebergQty          Decimal
+	Time                time.Time
 }
 
 // CancelOrderRequest represents CancelOrder request data.
@@ -240,16 +343,11 @@ type Account struct {
 	Balances        []*Balance
 }
 
-type AccountEvent struct {
-	WSEvent
-	Account
-}
-
 // Balance groups balance-related information.
 type Balance struct {
 	Asset  string
-	Free   float64
-	Locked float64
+	Free   Decimal
+	Locked Decimal
 }
 
 // MyTradesRequest represents MyTrades request data.
@@ -264,9 +362,9 @@ type MyTradesRequest struct {
 // Trade represents data about trade.
 type Trade struct {
 	ID              int64
-	Price           float64
-	Qty             float64
-	Commission      float64
+	Price           Decimal
+	Qty             Decimal
+	Commission      Decimal
 	CommissionAsset string
 	Time            time.Time
 	IsBuyer         bool
@@ -278,7 +376,7 @@ type Trade struct {
 type WithdrawRequest struct {
 	Asset      string
 	Address    string
-	Amount     float64
+	Amount     Decimal
 	Name       string
 	RecvWindow time.Duration
 	Timestamp  time.Time
@@ -286,6 +384,7 @@ type WithdrawRequest struct {
 
 // WithdrawResult represents Withdraw result.
 type WithdrawResult struct {
+	ID      string
 	Success bool
 	Msg     string
 }
@@ -303,14 +402,14 @@ type HistoryRequest struct {
 // Deposit represents Deposit data.
 type Deposit struct {
 	InsertTime time.Time
-	Amount     float64
+	Amount     Decimal
 	Asset      string
 	Status     int
 }
 
 // Withdrawal represents withdrawal data.
 type Withdrawal struct {
-	Amount    float64
+	Amount    Decimal
 	Address   string
 	TxID      string
 	Asset     string
@@ -318,6 +417,59 @@ type Withdrawal struct {
 	Status    int
 }
 
+// UserDataEvent represents user data stream event. Exactly one of
+// AccountPosition, BalanceUpdate and ExecutionReport is set, depending on
+// event Type.
+type UserDataEvent struct {
+	WSEvent
+	AccountPosition *AccountPosition
+	BalanceUpdate   *BalanceUpdate
+	ExecutionReport *ExecutionReport
+}
+
+// AccountPosition represents balances of assets changed by account update.
+type AccountPosition struct {
+	LastUpdateTime time.Time
+	Balances       []*Balance
+}
+
+// BalanceUpdate represents deposit, withdrawal or transfer balance delta.
+type BalanceUpdate struct {
+	Asset     string
+	Delta     Decimal
+	ClearTime time.Time
+}
+
+// ExecutionReport represents order update.
+type ExecutionReport struct {
+	Symbol              string
+	OrderID             int64
+	ClientOrderID       string
+	OrigClientOrderID   string
+	Side                OrderSide
+	Type                OrderType
+	TimeInForce         TimeInForce
+	Quantity            Decimal
+	Price               Decimal
+	StopPrice           Decimal
+	IcebergQty          Decimal
+	ExecutionType       ExecutionType
+	Status              OrderStatus
+	RejectReason        string
+	LastFilledQty       Decimal
+	LastFilledPrice     Decimal
+	LastQuoteQty        Decimal
+	CumulativeFilledQty Decimal
+	CumulativeQuoteQty  Decimal
+	Commission          Decimal
+	CommissionAsset     string
+	TradeID             int64
+	IsMaker             bool
+	IsWorking           bool
+	TransactionTime     time.Time
+	CreationTime        time.Time
+}
+
 // Stream represents stream information.
 //
 // Read web docs to get more information about using streams.
@@ -331,8 +483,11 @@ type WSEvent struct {
 	Symbol string
 }
 
+// DepthWebsocketRequest represents DepthWebsocket request data. UpdateSpeed
+// is either 100ms or 1s, zero means 1s.
 type DepthWebsocketRequest struct {
-	Symbol string
+	Symbol      string
+	UpdateSpeed time.Duration
 }
 
 type KlineWebsocketRequest struct {
@@ -344,6 +499,19 @@ type TradeWebsocketRequest struct {
 	Symbol string
 }
 
+// TickerWebsocketRequest represents TickerWebsocket, MiniTickerWebsocket and
+// BookTickerWebsocket request data. Empty Symbol means all symbols, except
+// for BookTickerWebsocket, which requires Symbol since the exchange removed
+// all-symbol book ticker stream.
+type TickerWebsocketRequest struct {
+	Symbol string
+}
+
+// UserDataWebsocketRequest represents UserDataWebsocket request data.
+//
+// Empty ListenKey makes client obtain listen key itself and keep it alive
+// every KeepAliveInterval (30 minutes by default).
 type UserDataWebsocketRequest struct {
-	ListenKey string
+	ListenKey         string
+	KeepAliveInterval time.Duration
 }
@@ -0,0 +1,188 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+)
+
+// orderBookPrecision is number of decimal places of computed prices and
+// ratios.
+const orderBookPrecision = 16
+
+var (
+	// ErrInsufficientDepth means book side lacks liquidity for requested
+	// quantity.
+	ErrInsufficientDepth = errors.New("insufficient order book depth")
+
+	bpsMultiplier = NewDecimal(10000, 0)
+)
+
+// ExecutionEstimate describes market order executed against order book.
+type ExecutionEstimate struct {
+	Side          OrderSide
+	Quantity      Decimal
+	QuoteQuantity Decimal
+	AveragePrice  Decimal
+	// WorstPrice is price of the last level order reaches.
+	WorstPrice Decimal
+	Levels     int
+}
+
+// BestBid returns the highest bid, Bids are expected sorted by price
+// descending.
+func (ob *OrderBook) BestBid() (*Order, bool) {
+	if len(ob.Bids) == 0 {
+		return nil, false
+	}
+	return ob.Bids[0], true
+}
+
+// BestAsk returns the lowest ask, Asks are expected sorted by price
+// ascending.
+func (ob *OrderBook) BestAsk() (*Order, bool) {
+	if len(ob.Asks) == 0 {
+		return nil, false
+	}
+	return ob.Asks[0], true
+}
+
+// MidPrice returns average of best bid and best ask.
+func (ob *OrderBook) MidPrice() (Decimal, bool) {
+	bid, ask, ok := ob.top()
+	if !ok {
+		return Decimal{}, false
+	}
+	mid, _ := bid.Price.Add(ask.Price).Div(NewDecimal(2, 0), orderBookPrecision)
+	return mid, true
+}
+
+// Spread returns difference between best ask and best bid.
+func (ob *OrderBook) Spread() (Decimal, bool) {
+	bid, ask, ok := ob.top()
+	if !ok {
+		return Decimal{}, false
+	}
+	return ask.Price.Sub(bid.Price), true
+}
+
+// SpreadBps returns spread in basis points of mid price.
+func (ob *OrderBook) SpreadBps() (Decimal, bool) {
+	spread, ok := ob.Spread()
+	if !ok {
+		return Decimal{}, false
+	}
+	mid, _ := ob.MidPrice()
+	bps, err := spread.Mul(bpsMultiplier).Div(mid, orderBookPrecision)
+	if err != nil {
+		return Decimal{}, false
+	}
+	return bps, true
+}
+
+// EstimateExecution returns execution of market order of given side and
+// base quantity: buy takes asks, sell takes bids.
+func (ob *OrderBook) EstimateExecution(side OrderSide, quantity Decimal) (*ExecutionEstimate, error) {
+	return ob.estimate(side, quantity, false)
+}
+
+// EstimateQuoteExecution returns execution of market order of given side
+// spending or receiving quoteQuantity.
+func (ob *OrderBook) EstimateQuoteExecution(side OrderSide, quoteQuantity Decimal) (*ExecutionEstimate, error) {
+	return ob.estimate(side, quoteQuantity, true)
+}
+
+// SlippageBps returns how much average price of market order of given side
+// and base quantity is worse than mid price, in basis points.
+func (ob *OrderBook) SlippageBps(side OrderSide, quantity Decimal) (Decimal, error) {
+	mid, ok := ob.MidPrice()
+	if !ok {
+		return Decimal{}, ErrInsufficientDepth
+	}
+	estimate, err := ob.EstimateExecution(side, quantity)
+	if err != nil {
+		return Decimal{}, err
+	}
+	diff := estimate.AveragePrice.Sub(mid)
+	if side == SideSell {
+		diff = diff.Neg()
+	}
+	return diff.Mul(bpsMultiplier).Div(mid, orderBookPrecision)
+}
+
+// Imbalance returns (bids - asks) / (bids + asks) of quantities on top
+// levels of both sides, ranging from -1 when there are only asks to 1 when
+// there are only bids. Non-positive levels means the whole book.
+func (ob *OrderBook) Imbalance(levels int) (Decimal, bool) {
+	bids, asks := sumQuantity(ob.Bids, levels), sumQuantity(ob.Asks, levels)
+	imbalance, err := bids.Sub(asks).Div(bids.Add(asks), orderBookPrecision)
+	if err != nil {
+		return Decimal{}, false
+	}
+	return imbalance, true
+}
+
+func (ob *OrderBook) top() (*Order, *Order, bool) {
+	bid, ok := ob.BestBid()
+	if !ok {
+		return nil, nil, false
+	}
+	ask, ok := ob.BestAsk()
+	if !ok {
+		return nil, nil, false
+	}
+	return bid, ask, true
+}
+
+// estimate walks levels taken by order until amount, base or quote one, is
+// filled.
+func (ob *OrderBook) estimate(side OrderSide, amount Decimal, quote bool) (*ExecutionEstimate, error) {
+	var levels []*Order
+	switch side {
+	case SideBuy:
+		levels = ob.Asks
+	case SideSell:
+		levels = ob.Bids
+	default:
+		return nil, fmt.Errorf("unknown order side %q", side)
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("non-positive amount %s", amount)
+	}
+	estimate := &ExecutionEstimate{Side: side}
+	remaining := amount
+	for _, level := range levels {
+		quantity, quoteQuantity := level.Quantity, level.Price.Mul(level.Quantity)
+		if quote && quoteQuantity.GreaterThan(remaining) {
+			quoteQuantity = remaining
+			quantity, _ = remaining.Div(level.Price, orderBookPrecision)
+		} else if !quote && quantity.GreaterThan(remaining) {
+			quantity = remaining
+			quoteQuantity = level.Price.Mul(remaining)
+		}
+		estimate.Quantity = estimate.Quantity.Add(quantity)
+		estimate.QuoteQuantity = estimate.QuoteQuantity.Add(quoteQuantity)
+		estimate.WorstPrice = level.Price
+		estimate.Levels++
+		if quote {
+			remaining = remaining.Sub(quoteQuantity)
+		} else {
+			remaining = remaining.Sub(quantity)
+		}
+		if remaining.Sign() <= 0 {
+			estimate.AveragePrice, _ = estimate.QuoteQuantity.Div(estimate.Quantity, orderBookPrecision)
+			return estimate, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s of %s available", ErrInsufficientDepth, amount.Sub(remaining), amount)
+}
+
+func sumQuantity(levels []*Order, n int) Decimal {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	var sum Decimal
+	for _, level := range levels[:n] {
+		sum = sum.Add(level.Quantity)
+	}
+	return sum
+}
@@ -9,6 +9,13 @@ type OrderType string
 // OrderSide represents order side enum.
 type OrderSide string
 
+// ExecutionType represents execution type enum of ExecutionReport.
+type ExecutionType string
+
+// OrderResponseType represents newOrderRespType enum, verbosity of NewOrder
+// response.
+type OrderResponseType string
+
 var (
 	StatusNew             = OrderStatus("NEW")
 	StatusPartiallyFilled = OrderStatus("PARTIALLY_FILLED")
@@ -18,9 +25,26 @@ var (
 	StatusRejected        = OrderStatus("REJECTED")
 	StatusExpired         = OrderStatus("EXPIRED")
 
-	TypeLimit  = OrderType("LIMIT")
-	TypeMarket = OrderType("MARKET")
+	TypeLimit           = OrderType("LIMIT")
+	TypeMarket          = OrderType("MARKET")
+	TypeStopLoss        = OrderType("STOP_LOSS")
+	TypeStopLossLimit   = OrderType("STOP_LOSS_LIMIT")
+	TypeTakeProfit      = OrderType("TAKE_PROFIT")
+	TypeTakeProfitLimit = OrderType("TAKE_PROFIT_LIMIT")
+	TypeLimitMaker      = OrderType("LIMIT_MAKER")
 
 	SideBuy  = OrderSide("BUY")
 	SideSell = OrderSide("SELL")
+
+	ExecutionTypeNew             = ExecutionType("NEW")
+	ExecutionTypeCanceled        = ExecutionType("CANCELED")
+	ExecutionTypeReplaced        = ExecutionType("REPLACED")
+	ExecutionTypeRejected        = ExecutionType("REJECTED")
+	ExecutionTypeTrade           = ExecutionType("TRADE")
+	ExecutionTypeExpired         = ExecutionType("EXPIRED")
+	ExecutionTypeTradePrevention = ExecutionType("TRADE_PREVENTION")
+
+	ResponseTypeAck    = OrderResponseType("ACK")
+	ResponseTypeResult = OrderResponseType("RESULT")
+	ResponseTypeFull   = OrderResponseType("FULL")
 )
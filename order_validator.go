@@ -0,0 +1,323 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+)
+
+// ValidationMode defines how OrderValidator treats price and quantity which
+// don't match symbol filters.
+type ValidationMode int
+
+const (
+	// ValidationReject rejects such requests with ValidationError.
+	ValidationReject ValidationMode = iota
+	// ValidationRound rounds price to nearest tick and quantity down to
+	// step, then validates the result.
+	ValidationRound
+)
+
+// ValidationError describes NewOrderRequest field violating symbol rules.
+type ValidationError struct {
+	Symbol string
+	Field  string
+	Reason string
+}
+
+// Error returns formatted error message.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s order %s: %s", e.Symbol, e.Field, e.Reason)
+}
+
+// timeInForceRules tells for each order type whether TimeInForce is
+// required (true) or must be empty (false). Types missing here accept both.
+var timeInForceRules = map[OrderType]bool{
+	TypeLimit:           true,
+	TypeMarket:          false,
+	TypeStopLoss:        false,
+	TypeStopLossLimit:   true,
+	TypeTakeProfit:      false,
+	TypeTakeProfitLimit: true,
+	TypeLimitMaker:      false,
+}
+
+// orderFields tells which of NewOrderRequest fields order type requires.
+// Fields not required must be empty, except Quantity, which is always
+// required unless QuoteOrderQty replaces it in market order.
+type orderFields struct {
+	price bool
+	// stop means StopPrice or TrailingDelta.
+	stop bool
+}
+
+var orderFieldRules = map[OrderType]orderFields{
+	TypeLimit:           {price: true},
+	TypeMarket:          {},
+	TypeStopLoss:        {stop: true},
+	TypeStopLossLimit:   {price: true, stop: true},
+	TypeTakeProfit:      {stop: true},
+	TypeTakeProfitLimit: {price: true, stop: true},
+	TypeLimitMaker:      {price: true},
+}
+
+// marketOrderTypes are types executed as market orders, so they are subject
+// to MARKET_LOT_SIZE filter.
+var marketOrderTypes = map[OrderType]bool{
+	TypeMarket:     true,
+	TypeStopLoss:   true,
+	TypeTakeProfit: true,
+}
+
+// OrderValidator checks NewOrderRequest against exchange filters before it's
+// sent, saving request weight on orders the exchange would reject.
+//
+// PERCENT_PRICE and MAX_NUM_ORDERS depend on market and account state and
+// are left to the exchange.
+type OrderValidator struct {
+	exchangeInfo *ExchangeInfoCache
+	mode         ValidationMode
+}
+
+// NewOrderValidator returns validator reading symbol filters from
+// exchangeInfo.
+func NewOrderValidator(exchangeInfo *ExchangeInfoCache, mode ValidationMode) *OrderValidator {
+	return &OrderValidator{
+		exchangeInfo: exchangeInfo,
+		mode:         mode,
+	}
+}
+
+// Validate returns request normalized according to validator mode or error
+// describing the first violated rule.
+func (v *OrderValidator) Validate(ctx context.Context, nor NewOrderRequest) (NewOrderRequest, error) {
+	si, err := v.exchangeInfo.Symbol(ctx, nor.Symbol)
+	if err != nil {
+		return nor, err
+	}
+	return ValidateOrder(si, nor, v.mode)
+}
+
+// ValidateOrder checks nor against rules of si. In ValidationRound mode
+// price, stop price and quantity are rounded before being checked.
+func ValidateOrder(si *SymbolInfo, nor NewOrderRequest, mode ValidationMode) (NewOrderRequest, error) {
+	invalid := func(field, format string, args ...interface{}) error {
+		return &ValidationError{Symbol: nor.Symbol, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if si.Status != SymbolStatusTrading {
+		return nor, invalid("symbol", "symbol status is %s", si.Status)
+	}
+	if !si.AllowsOrderType(nor.Type) {
+		return nor, invalid("type", "order type %s is not allowed", nor.Type)
+	}
+	if err := CheckOrderRequest(nor); err != nil {
+		return nor, err
+	}
+	if nor.IcebergQty.Sign() > 0 && !si.IcebergAllowed {
+		return nor, invalid("icebergQty", "iceberg orders are not allowed")
+	}
+
+	// PRICE_FILTER applies to stop price too.
+	if pf := si.Filters.Price; pf != nil {
+		for _, p := range []struct {
+			field string
+			price *Decimal
+		}{
+			{"price", &nor.Price},
+			{"stopPrice", &nor.StopPrice},
+		} {
+			if p.price.Sign() <= 0 {
+				continue
+			}
+			offset := p.price.Sub(pf.MinPrice)
+			if mode == ValidationRound {
+				price := *p.price
+				*p.price = pf.MinPrice.Add(offset.RoundToStep(pf.TickSize))
+				// Price below half a tick would be rounded to zero and
+				// then dropped from request.
+				if p.price.Sign() <= 0 {
+					return nor, invalid(p.field, "%s rounds to %s with tick size %s", price, *p.price, pf.TickSize)
+				}
+			} else if !offset.IsMultipleOf(pf.TickSize) {
+				return nor, invalid(p.field, "%s is not multiple of tick size %s", *p.price, pf.TickSize)
+			}
+			if pf.MinPrice.Sign() > 0 && p.price.LessThan(pf.MinPrice) {
+				return nor, invalid(p.field, "%s is less than %s", *p.price, pf.MinPrice)
+			}
+			if pf.MaxPrice.Sign() > 0 && p.price.GreaterThan(pf.MaxPrice) {
+				return nor, invalid(p.field, "%s is greater than %s", *p.price, pf.MaxPrice)
+			}
+		}
+	}
+
+	if td := si.Filters.TrailingDelta; td != nil && nor.TrailingDelta > 0 {
+		minDelta, maxDelta := td.MinTrailingBelowDelta, td.MaxTrailingBelowDelta
+		if triggersAbove(nor) {
+			minDelta, maxDelta = td.MinTrailingAboveDelta, td.MaxTrailingAboveDelta
+		}
+		if nor.TrailingDelta < minDelta {
+			return nor, invalid("trailingDelta", "%d is less than %d", nor.TrailingDelta, minDelta)
+		}
+		if maxDelta > 0 && nor.TrailingDelta > maxDelta {
+			return nor, invalid("trailingDelta", "%d is greater than %d", nor.TrailingDelta, maxDelta)
+		}
+	}
+
+	lotSizes := []*LotSizeFilter{si.Filters.LotSize}
+	if marketOrderTypes[nor.Type] {
+		lotSizes = append(lotSizes, si.Filters.MarketLotSize)
+	}
+	for _, ls := range lotSizes {
+		if ls == nil || nor.Quantity.Sign() <= 0 {
+			continue
+		}
+		offset := nor.Quantity.Sub(ls.MinQty)
+		if mode == ValidationRound {
+			if offset.Sign() > 0 {
+				nor.Quantity = ls.MinQty.Add(offset.TruncateToStep(ls.StepSize))
+			}
+		} else if !offset.IsMultipleOf(ls.StepSize) {
+			return nor, invalid("quantity", "%s is not multiple of step size %s", nor.Quantity, ls.StepSize)
+		}
+		if nor.Quantity.LessThan(ls.MinQty) {
+			return nor, invalid("quantity", "%s is less than %s", nor.Quantity, ls.MinQty)
+		}
+		if ls.MaxQty.Sign() > 0 && nor.Quantity.GreaterThan(ls.MaxQty) {
+			return nor, invalid("quantity", "%s is greater than %s", nor.Quantity, ls.MaxQty)
+		}
+	}
+
+	// Notional of market orders depends on average price, which is
+	// unknown here, unless it's given as QuoteOrderQty.
+	if nor.QuoteOrderQty.Sign() > 0 {
+		if mn := si.Filters.MinNotional; mn != nil && mn.ApplyToMarket && nor.QuoteOrderQty.LessThan(mn.MinNotional) {
+			return nor, invalid("quoteOrderQty", "%s is less than %s", nor.QuoteOrderQty, mn.MinNotional)
+		}
+		if n := si.Filters.Notional; n != nil {
+			if n.ApplyMinToMarket && nor.QuoteOrderQty.LessThan(n.MinNotional) {
+				return nor, invalid("quoteOrderQty", "%s is less than %s", nor.QuoteOrderQty, n.MinNotional)
+			}
+			if n.ApplyMaxToMarket && n.MaxNotional.Sign() > 0 && nor.QuoteOrderQty.GreaterThan(n.MaxNotional) {
+				return nor, invalid("quoteOrderQty", "%s is greater than %s", nor.QuoteOrderQty, n.MaxNotional)
+			}
+		}
+	}
+	if !marketOrderTypes[nor.Type] && nor.Price.Sign() > 0 && nor.Quantity.Sign() > 0 {
+		notional := nor.Price.Mul(nor.Quantity)
+		if mn := si.Filters.MinNotional; mn != nil && notional.LessThan(mn.MinNotional) {
+			return nor, invalid("notional", "%s is less than %s", notional, mn.MinNotional)
+		}
+		if n := si.Filters.Notional; n != nil {
+			if notional.LessThan(n.MinNotional) {
+				return nor, invalid("notional", "%s is less than %s", notional, n.MinNotional)
+			}
+			if n.MaxNotional.Sign() > 0 && notional.GreaterThan(n.MaxNotional) {
+				return nor, invalid("notional", "%s is greater than %s", notional, n.MaxNotional)
+			}
+		}
+	}
+	return nor, nil
+}
+
+// CheckOrderRequest checks rules of nor which don't depend on symbol:
+// fields required by order type, its TimeInForce and iceberg quantity. Client
+// checks them before sending order, ValidateOrder checks them as well.
+func CheckOrderRequest(nor NewOrderRequest) error {
+	invalid := func(field, format string, args ...interface{}) error {
+		return &ValidationError{Symbol: nor.Symbol, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+	if required, ok := timeInForceRules[nor.Type]; ok {
+		if required && nor.TimeInForce == "" {
+			return invalid("timeInForce", "required for %s order", nor.Type)
+		}
+		if !required && nor.TimeInForce != "" {
+			return invalid("timeInForce", "not allowed for %s order", nor.Type)
+		}
+	}
+	if err := checkOrderFields(nor, invalid); err != nil {
+		return err
+	}
+	// Iceberg order has to stay in the book.
+	if nor.IcebergQty.Sign() > 0 && nor.TimeInForce != "" && nor.TimeInForce != GTC {
+		return invalid("icebergQty", "not allowed with timeInForce %s", nor.TimeInForce)
+	}
+	return nil
+}
+
+// checkOrderFields checks that fields required by order type are set and
+// others are empty.
+func checkOrderFields(nor NewOrderRequest, invalid func(field, format string, args ...interface{}) error) error {
+	rules, ok := orderFieldRules[nor.Type]
+	if !ok {
+		return nil
+	}
+	quantity, quoteOrderQty := nor.Quantity.Sign() > 0, nor.QuoteOrderQty.Sign() > 0
+	switch {
+	case quoteOrderQty && nor.Type != TypeMarket:
+		return invalid("quoteOrderQty", "not allowed for %s order", nor.Type)
+	case quantity && quoteOrderQty:
+		return invalid("quoteOrderQty", "not allowed with quantity")
+	case !quantity && !quoteOrderQty:
+		return invalid("quantity", "required for %s order", nor.Type)
+	}
+	price := nor.Price.Sign() > 0
+	if rules.price && !price {
+		return invalid("price", "required for %s order", nor.Type)
+	}
+	if !rules.price && price {
+		return invalid("price", "not allowed for %s order", nor.Type)
+	}
+	stop := nor.StopPrice.Sign() > 0 || nor.TrailingDelta > 0
+	if rules.stop && !stop {
+		return invalid("stopPrice", "stopPrice or trailingDelta required for %s order", nor.Type)
+	}
+	if !rules.stop && stop {
+		return invalid("stopPrice", "stopPrice and trailingDelta not allowed for %s order", nor.Type)
+	}
+	return nil
+}
+
+// triggersAbove reports whether stop order is triggered by price rising
+// above its stop price: buy stop loss and sell take profit.
+func triggersAbove(nor NewOrderRequest) bool {
+	switch nor.Type {
+	case TypeStopLoss, TypeStopLossLimit:
+		return nor.Side == SideBuy
+	case TypeTakeProfit, TypeTakeProfitLimit:
+		return nor.Side == SideSell
+	}
+	return false
+}
+
+// ValidatingClient is Client which validates orders with OrderValidator
+// before sending them.
+type ValidatingClient struct {
+	Client
+	validator *OrderValidator
+}
+
+// NewValidatingClient wraps client with order validation.
+func NewValidatingClient(client Client, validator *OrderValidator) *ValidatingClient {
+	return &ValidatingClient{
+		Client:    client,
+		validator: validator,
+	}
+}
+
+// NewOrder validates nor and places it.
+func (c *ValidatingClient) NewOrder(ctx context.Context, nor NewOrderRequest) (*ProcessedOrder, error) {
+	nor, err := c.validator.Validate(ctx, nor)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.NewOrder(ctx, nor)
+}
+
+// NewOrderTest validates nor and places it as testing order.
+func (c *ValidatingClient) NewOrderTest(ctx context.Context, nor NewOrderRequest) error {
+	nor, err := c.validator.Validate(ctx, nor)
+	if err != nil {
+		return err
+	}
+	return c.Client.NewOrderTest(ctx, nor)
+}
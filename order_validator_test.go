@@ -0,0 +1,366 @@
+package binance
+
+import (
+	"errors"
+	"testing"
+)
+
+func testSymbolInfo() *SymbolInfo {
+	return &SymbolInfo{
+		Symbol: "BTCUSDT",
+		Status: SymbolStatusTrading,
+		OrderTypes: []OrderType{
+			TypeLimit, TypeMarket, TypeStopLoss, TypeStopLossLimit,
+			TypeTakeProfit, TypeTakeProfitLimit, TypeLimitMaker,
+		},
+		Filters: SymbolFilters{
+			Price: &PriceFilter{
+				MinPrice: MustParseDecimal("0.01"),
+				MaxPrice: MustParseDecimal("1000"),
+				TickSize: MustParseDecimal("0.01"),
+			},
+			LotSize: &LotSizeFilter{
+				MinQty:   MustParseDecimal("0.001"),
+				MaxQty:   MustParseDecimal("100"),
+				StepSize: MustParseDecimal("0.001"),
+			},
+			MarketLotSize: &LotSizeFilter{
+				MinQty:   MustParseDecimal("0.01"),
+				MaxQty:   MustParseDecimal("10"),
+				StepSize: MustParseDecimal("0.01"),
+			},
+			MinNotional: &MinNotionalFilter{
+				MinNotional:   MustParseDecimal("10"),
+				ApplyToMarket: true,
+			},
+			Notional: &NotionalFilter{
+				MinNotional: MustParseDecimal("5"),
+				MaxNotional: MustParseDecimal("10000"),
+			},
+		},
+	}
+}
+
+func limitOrder(price, quantity string) NewOrderRequest {
+	return NewOrderRequest{
+		Symbol:      "BTCUSDT",
+		Side:        SideBuy,
+		Type:        TypeLimit,
+		TimeInForce: GTC,
+		Price:       MustParseDecimal(price),
+		Quantity:    MustParseDecimal(quantity),
+	}
+}
+
+func marketOrder(quantity, quoteOrderQty string) NewOrderRequest {
+	nor := NewOrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: TypeMarket}
+	if quantity != "" {
+		nor.Quantity = MustParseDecimal(quantity)
+	}
+	if quoteOrderQty != "" {
+		nor.QuoteOrderQty = MustParseDecimal(quoteOrderQty)
+	}
+	return nor
+}
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    ValidationMode
+		filters func(*SymbolFilters)
+		nor     NewOrderRequest
+		// wantField is field of expected ValidationError, empty if request
+		// is valid.
+		wantField    string
+		wantPrice    string
+		wantQuantity string
+	}{
+		{name: "valid", nor: limitOrder("20.01", "1.234"), wantPrice: "20.01", wantQuantity: "1.234"},
+		{name: "price off tick", nor: limitOrder("20.005", "1"), wantField: "price"},
+		{
+			name:      "price below min",
+			filters:   func(f *SymbolFilters) { f.Price.MinPrice = MustParseDecimal("1") },
+			nor:       limitOrder("0.5", "100"),
+			wantField: "price",
+		},
+		{name: "price above max", nor: limitOrder("1000.01", "1"), wantField: "price"},
+		{name: "quantity off step", nor: limitOrder("20", "1.2345"), wantField: "quantity"},
+		{
+			name:      "quantity below min",
+			filters:   func(f *SymbolFilters) { f.LotSize.MinQty = MustParseDecimal("1") },
+			nor:       limitOrder("20", "0.5"),
+			wantField: "quantity",
+		},
+		{name: "quantity above max", nor: limitOrder("20", "100.001"), wantField: "quantity"},
+		{name: "market lot size", nor: marketOrder("20", ""), wantField: "quantity"},
+		{name: "market lot size step", nor: marketOrder("1.005", ""), wantField: "quantity"},
+		{name: "min notional", nor: limitOrder("1", "5"), wantField: "notional"},
+		{
+			name:      "notional min",
+			filters:   func(f *SymbolFilters) { f.MinNotional = nil },
+			nor:       limitOrder("1", "2"),
+			wantField: "notional",
+		},
+		{name: "notional max", nor: limitOrder("999", "20"), wantField: "notional"},
+		{name: "market min notional", nor: marketOrder("", "5"), wantField: "quoteOrderQty"},
+		{
+			name:      "market min notional not applied",
+			filters:   func(f *SymbolFilters) { f.MinNotional.ApplyToMarket = false },
+			nor:       marketOrder("", "5"),
+			wantPrice: "0", wantQuantity: "0",
+		},
+		{
+			name:      "market notional max",
+			filters:   func(f *SymbolFilters) { f.Notional.ApplyMaxToMarket = true },
+			nor:       marketOrder("", "10001"),
+			wantField: "quoteOrderQty",
+		},
+		{
+			name:      "market order ignores notional of quantity",
+			nor:       marketOrder("0.01", ""),
+			wantPrice: "0", wantQuantity: "0.01",
+		},
+
+		{name: "round", mode: ValidationRound, nor: limitOrder("20.005", "1.2345"), wantPrice: "20.01", wantQuantity: "1.234"},
+		{name: "round down", mode: ValidationRound, nor: limitOrder("20.004", "1.2349"), wantPrice: "20", wantQuantity: "1.234"},
+		{
+			name:         "round to min price offset",
+			mode:         ValidationRound,
+			filters:      func(f *SymbolFilters) { f.Price.MinPrice = MustParseDecimal("0.015") },
+			nor:          limitOrder("20.004", "1"),
+			wantPrice:    "20.005",
+			wantQuantity: "1",
+		},
+		{name: "round above max", mode: ValidationRound, nor: limitOrder("1000.006", "1"), wantField: "price"},
+		{name: "round within max", mode: ValidationRound, nor: limitOrder("1000.004", "1"), wantPrice: "1000", wantQuantity: "1"},
+		{
+			name:      "round price to zero",
+			mode:      ValidationRound,
+			filters:   func(f *SymbolFilters) { f.Price.MinPrice = Decimal{} },
+			nor:       limitOrder("0.004", "1"),
+			wantField: "price",
+		},
+		{name: "round quantity below min", mode: ValidationRound, nor: limitOrder("20", "0.0009"), wantField: "quantity"},
+		{name: "round quantity above max", mode: ValidationRound, nor: limitOrder("20", "100.0019"), wantField: "quantity"},
+		{name: "round market lot size", mode: ValidationRound, nor: marketOrder("1.009", ""), wantPrice: "0", wantQuantity: "1"},
+		{name: "round below min notional", mode: ValidationRound, nor: limitOrder("10", "0.9999"), wantField: "notional"},
+	}
+	for _, tt := range tests {
+		si := testSymbolInfo()
+		if tt.filters != nil {
+			tt.filters(&si.Filters)
+		}
+		got, err := ValidateOrder(si, tt.nor, tt.mode)
+		if tt.wantField != "" {
+			var verr *ValidationError
+			if !errors.As(err, &verr) || verr.Field != tt.wantField {
+				t.Errorf("%s: error = %v, want invalid %s", tt.name, err, tt.wantField)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.Price.String() != tt.wantPrice || got.Quantity.String() != tt.wantQuantity {
+			t.Errorf("%s: price %s, quantity %s, want %s, %s", tt.name, got.Price, got.Quantity, tt.wantPrice, tt.wantQuantity)
+		}
+	}
+}
+
+func TestValidateOrderTimeInForce(t *testing.T) {
+	price, stopPrice, quantity := MustParseDecimal("20"), MustParseDecimal("19"), MustParseDecimal("1")
+	orders := map[OrderType]NewOrderRequest{
+		TypeLimit:           {Price: price, Quantity: quantity},
+		TypeMarket:          {Quantity: quantity},
+		TypeStopLoss:        {StopPrice: stopPrice, Quantity: quantity},
+		TypeStopLossLimit:   {Price: price, StopPrice: stopPrice, Quantity: quantity},
+		TypeTakeProfit:      {StopPrice: stopPrice, Quantity: quantity},
+		TypeTakeProfitLimit: {Price: price, StopPrice: stopPrice, Quantity: quantity},
+		TypeLimitMaker:      {Price: price, Quantity: quantity},
+	}
+	for orderType, nor := range orders {
+		nor.Symbol, nor.Side, nor.Type = "BTCUSDT", SideBuy, orderType
+		required := timeInForceRules[orderType]
+		for _, tif := range []TimeInForce{"", GTC} {
+			nor.TimeInForce = tif
+			_, err := ValidateOrder(testSymbolInfo(), nor, ValidationReject)
+			var verr *ValidationError
+			invalidTIF := errors.As(err, &verr) && verr.Field == "timeInForce"
+			if wantInvalid := required == (tif == ""); invalidTIF != wantInvalid || (!wantInvalid && err != nil) {
+				t.Errorf("%s with timeInForce %q: %v", orderType, tif, err)
+			}
+		}
+	}
+}
+
+func TestValidateOrderSymbol(t *testing.T) {
+	si := testSymbolInfo()
+	si.OrderTypes = []OrderType{TypeLimit}
+	var verr *ValidationError
+	if _, err := ValidateOrder(si, marketOrder("1", ""), ValidationReject); !errors.As(err, &verr) || verr.Field != "type" {
+		t.Errorf("disallowed order type: %v", err)
+	}
+	si.Status = SymbolStatusHalt
+	if _, err := ValidateOrder(si, limitOrder("20", "1"), ValidationReject); !errors.As(err, &verr) || verr.Field != "symbol" {
+		t.Errorf("halted symbol: %v", err)
+	}
+}
+
+func TestCheckOrderRequest(t *testing.T) {
+	one := MustParseDecimal("1")
+	tests := []struct {
+		nor NewOrderRequest
+		// wantField is field of expected ValidationError, empty if request
+		// is valid.
+		wantField string
+	}{
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: GTC, Price: one, Quantity: one}, ""},
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: GTC, Quantity: one}, "price"},
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: GTC, Price: one}, "quantity"},
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: GTC, Price: one, QuoteOrderQty: one}, "quoteOrderQty"},
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: GTC, Price: one, Quantity: one, StopPrice: one}, "stopPrice"},
+		{NewOrderRequest{Type: TypeLimit, Price: one, Quantity: one}, "timeInForce"},
+
+		{NewOrderRequest{Type: TypeMarket, Quantity: one}, ""},
+		{NewOrderRequest{Type: TypeMarket, QuoteOrderQty: one}, ""},
+		{NewOrderRequest{Type: TypeMarket}, "quantity"},
+		{NewOrderRequest{Type: TypeMarket, Quantity: one, QuoteOrderQty: one}, "quoteOrderQty"},
+		{NewOrderRequest{Type: TypeMarket, Quantity: one, Price: one}, "price"},
+		{NewOrderRequest{Type: TypeMarket, Quantity: one, TrailingDelta: 100}, "stopPrice"},
+		{NewOrderRequest{Type: TypeMarket, TimeInForce: GTC, Quantity: one}, "timeInForce"},
+
+		{NewOrderRequest{Type: TypeStopLoss, Quantity: one, StopPrice: one}, ""},
+		{NewOrderRequest{Type: TypeStopLoss, Quantity: one, TrailingDelta: 100}, ""},
+		{NewOrderRequest{Type: TypeStopLoss, Quantity: one}, "stopPrice"},
+		{NewOrderRequest{Type: TypeStopLoss, Quantity: one, StopPrice: one, Price: one}, "price"},
+		{NewOrderRequest{Type: TypeStopLoss, QuoteOrderQty: one, StopPrice: one}, "quoteOrderQty"},
+
+		{NewOrderRequest{Type: TypeStopLossLimit, TimeInForce: GTC, Quantity: one, Price: one, StopPrice: one}, ""},
+		{NewOrderRequest{Type: TypeStopLossLimit, TimeInForce: GTC, Quantity: one, Price: one, TrailingDelta: 100}, ""},
+		{NewOrderRequest{Type: TypeStopLossLimit, TimeInForce: GTC, Quantity: one, Price: one}, "stopPrice"},
+		{NewOrderRequest{Type: TypeStopLossLimit, TimeInForce: GTC, Quantity: one, StopPrice: one}, "price"},
+		{NewOrderRequest{Type: TypeStopLossLimit, Quantity: one, Price: one, StopPrice: one}, "timeInForce"},
+
+		{NewOrderRequest{Type: TypeTakeProfit, Quantity: one, StopPrice: one}, ""},
+		{NewOrderRequest{Type: TypeTakeProfit, Quantity: one, StopPrice: one, Price: one}, "price"},
+		{NewOrderRequest{Type: TypeTakeProfit, Quantity: one}, "stopPrice"},
+		{NewOrderRequest{Type: TypeTakeProfit, TimeInForce: GTC, Quantity: one, StopPrice: one}, "timeInForce"},
+
+		{NewOrderRequest{Type: TypeTakeProfitLimit, TimeInForce: GTC, Quantity: one, Price: one, StopPrice: one}, ""},
+		{NewOrderRequest{Type: TypeTakeProfitLimit, TimeInForce: GTC, Quantity: one, Price: one}, "stopPrice"},
+		{NewOrderRequest{Type: TypeTakeProfitLimit, Quantity: one, Price: one, StopPrice: one}, "timeInForce"},
+
+		{NewOrderRequest{Type: TypeLimitMaker, Quantity: one, Price: one}, ""},
+		{NewOrderRequest{Type: TypeLimitMaker, Quantity: one}, "price"},
+		{NewOrderRequest{Type: TypeLimitMaker, Quantity: one, Price: one, StopPrice: one}, "stopPrice"},
+		{NewOrderRequest{Type: TypeLimitMaker, TimeInForce: GTC, Quantity: one, Price: one}, "timeInForce"},
+
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: GTC, Price: one, Quantity: one, IcebergQty: one}, ""},
+		{NewOrderRequest{Type: TypeLimitMaker, Price: one, Quantity: one, IcebergQty: one}, ""},
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: IOC, Price: one, Quantity: one, IcebergQty: one}, "icebergQty"},
+		{NewOrderRequest{Type: TypeLimit, TimeInForce: FOK, Price: one, Quantity: one, IcebergQty: one}, "icebergQty"},
+	}
+	for _, tt := range tests {
+		err := CheckOrderRequest(tt.nor)
+		var verr *ValidationError
+		switch {
+		case tt.wantField == "" && err != nil:
+			t.Errorf("%+v: %v", tt.nor, err)
+		case tt.wantField != "" && (!errors.As(err, &verr) || verr.Field != tt.wantField):
+			t.Errorf("%+v: error %v, want invalid %s", tt.nor, err, tt.wantField)
+		}
+	}
+}
+
+func TestValidateOrderIceberg(t *testing.T) {
+	nor := limitOrder("20", "1")
+	nor.IcebergQty = MustParseDecimal("0.1")
+	var verr *ValidationError
+	if _, err := ValidateOrder(testSymbolInfo(), nor, ValidationReject); !errors.As(err, &verr) || verr.Field != "icebergQty" {
+		t.Errorf("iceberg not allowed by symbol: %v", err)
+	}
+	si := testSymbolInfo()
+	si.IcebergAllowed = true
+	if _, err := ValidateOrder(si, nor, ValidationReject); err != nil {
+		t.Errorf("iceberg allowed by symbol: %v", err)
+	}
+}
+
+func TestValidateOrderStopPrice(t *testing.T) {
+	nor := NewOrderRequest{
+		Symbol:      "BTCUSDT",
+		Side:        SideSell,
+		Type:        TypeStopLossLimit,
+		TimeInForce: GTC,
+		Quantity:    MustParseDecimal("1"),
+		Price:       MustParseDecimal("20"),
+		StopPrice:   MustParseDecimal("20.005"),
+	}
+	var verr *ValidationError
+	if _, err := ValidateOrder(testSymbolInfo(), nor, ValidationReject); !errors.As(err, &verr) || verr.Field != "stopPrice" {
+		t.Errorf("stop price off tick: %v", err)
+	}
+	got, err := ValidateOrder(testSymbolInfo(), nor, ValidationRound)
+	if err != nil || got.StopPrice.String() != "20.01" {
+		t.Errorf("rounded stop price %s, %v", got.StopPrice, err)
+	}
+	nor.StopPrice = MustParseDecimal("1000.01")
+	if _, err := ValidateOrder(testSymbolInfo(), nor, ValidationReject); !errors.As(err, &verr) || verr.Field != "stopPrice" {
+		t.Errorf("stop price above max: %v", err)
+	}
+}
+
+func TestValidateOrderTrailingDelta(t *testing.T) {
+	si := testSymbolInfo()
+	si.Filters.TrailingDelta = &TrailingDeltaFilter{
+		MinTrailingAboveDelta: 10,
+		MaxTrailingAboveDelta: 2000,
+		MinTrailingBelowDelta: 20,
+		MaxTrailingBelowDelta: 500,
+	}
+	tests := []struct {
+		orderType OrderType
+		side      OrderSide
+		delta     int
+		valid     bool
+	}{
+		// Buy stop loss and sell take profit trigger above, use above bounds.
+		{TypeStopLoss, SideBuy, 10, true},
+		{TypeStopLoss, SideBuy, 9, false},
+		{TypeStopLoss, SideBuy, 2000, true},
+		{TypeStopLoss, SideBuy, 2001, false},
+		{TypeTakeProfit, SideSell, 1000, true},
+		{TypeTakeProfitLimit, SideSell, 2001, false},
+		{TypeStopLossLimit, SideBuy, 15, true},
+		// Sell stop loss and buy take profit trigger below.
+		{TypeStopLoss, SideSell, 20, true},
+		{TypeStopLoss, SideSell, 19, false},
+		{TypeStopLoss, SideSell, 500, true},
+		{TypeStopLoss, SideSell, 501, false},
+		{TypeTakeProfit, SideBuy, 1000, false},
+		{TypeTakeProfitLimit, SideBuy, 15, false},
+		{TypeStopLossLimit, SideSell, 300, true},
+	}
+	for _, tt := range tests {
+		nor := NewOrderRequest{
+			Symbol:        "BTCUSDT",
+			Side:          tt.side,
+			Type:          tt.orderType,
+			Quantity:      MustParseDecimal("1"),
+			TrailingDelta: tt.delta,
+		}
+		if timeInForceRules[tt.orderType] {
+			nor.TimeInForce = GTC
+			nor.Price = MustParseDecimal("20")
+		}
+		_, err := ValidateOrder(si, nor, ValidationReject)
+		var verr *ValidationError
+		switch {
+		case tt.valid && err != nil:
+			t.Errorf("%s %s delta %d: %v", tt.side, tt.orderType, tt.delta, err)
+		case !tt.valid && (!errors.As(err, &verr) || verr.Field != "trailingDelta"):
+			t.Errorf("%s %s delta %d: error %v, want invalid trailingDelta", tt.side, tt.orderType, tt.delta, err)
+		}
+	}
+}
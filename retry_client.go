@@ -0,0 +1,325 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryConfig configures RetryingClient. Zero fields are replaced with
+// defaults of DefaultRetryConfig, other values out of range are rejected by
+// Validate.
+type RetryConfig struct {
+	// MaxAttempts is total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is delay before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps exponentially growing delay.
+	MaxBackoff time.Duration
+	// Multiplier is growth factor of delay between attempts, at least 1.
+	// Multiplier of 1 makes backoff constant.
+	Multiplier float64
+	// Jitter is fraction of delay randomly subtracted from it, in [0, 1].
+	// NoJitter disables it.
+	Jitter float64
+	// Retryable decides whether failed call may be repeated.
+	Retryable func(error) bool
+}
+
+// DefaultRetryConfig is used for zero fields of RetryConfig.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.5,
+	Retryable:      IsRetryable,
+}
+
+// RetryingClient is Client which repeats calls failed with retryable errors
+// using exponential backoff with jitter. Delay requested by Retry-After of
+// rate limited responses takes precedence over backoff.
+//
+// Only read and otherwise idempotent calls are retried. NewOrder is retried
+// only when NewClientOrderID is set: before every retry the order is looked
+// up by that ID, so order accepted despite the error isn't placed twice.
+type RetryingClient struct {
+	Client
+	config RetryConfig
+}
+
+// NoJitter is RetryConfig.Jitter making delays exact.
+const NoJitter = -1
+
+// NewRetryingClient wraps client with retries. It returns error if config
+// is invalid.
+func NewRetryingClient(client Client, config RetryConfig) (*RetryingClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &RetryingClient{
+		Client: client,
+		config: config.withDefaults(),
+	}, nil
+}
+
+// Validate reports field out of range. Zero fields are valid, they mean
+// defaults.
+func (config RetryConfig) Validate() error {
+	switch {
+	case config.MaxAttempts < 0:
+		return fmt.Errorf("invalid retry config: negative MaxAttempts %d", config.MaxAttempts)
+	case config.InitialBackoff < 0:
+		return fmt.Errorf("invalid retry config: negative InitialBackoff %s", config.InitialBackoff)
+	case config.MaxBackoff < 0:
+		return fmt.Errorf("invalid retry config: negative MaxBackoff %s", config.MaxBackoff)
+	case config.Multiplier != 0 && !(config.Multiplier >= 1):
+		return fmt.Errorf("invalid retry config: Multiplier %v is less than 1", config.Multiplier)
+	case config.Jitter != NoJitter && !(config.Jitter >= 0 && config.Jitter <= 1):
+		return fmt.Errorf("invalid retry config: Jitter %v is out of [0, 1]", config.Jitter)
+	}
+	return nil
+}
+
+// withDefaults returns config with zero fields replaced by defaults.
+func (config RetryConfig) withDefaults() RetryConfig {
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = DefaultRetryConfig.MaxAttempts
+	}
+	if config.InitialBackoff == 0 {
+		config.InitialBackoff = DefaultRetryConfig.InitialBackoff
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = DefaultRetryConfig.MaxBackoff
+	}
+	if config.Multiplier == 0 {
+		config.Multiplier = DefaultRetryConfig.Multiplier
+	}
+	if config.Jitter == 0 {
+		config.Jitter = DefaultRetryConfig.Jitter
+	}
+	if config.Retryable == nil {
+		config.Retryable = DefaultRetryConfig.Retryable
+	}
+	return config
+}
+
+func (c *RetryingClient) Ping(ctx context.Context) error {
+	_, err := retry(ctx, c.config, func() (struct{}, error) {
+		return struct{}{}, c.Client.Ping(ctx)
+	})
+	return err
+}
+
+func (c *RetryingClient) Time(ctx context.Context) (time.Time, error) {
+	return retry(ctx, c.config, func() (time.Time, error) {
+		return c.Client.Time(ctx)
+	})
+}
+
+func (c *RetryingClient) ExchangeInfo(ctx context.Context, eir ExchangeInfoRequest) (*ExchangeInfo, error) {
+	return retry(ctx, c.config, func() (*ExchangeInfo, error) {
+		return c.Client.ExchangeInfo(ctx, eir)
+	})
+}
+
+func (c *RetryingClient) OrderBook(ctx context.Context, obr OrderBookRequest) (*OrderBook, error) {
+	return retry(ctx, c.config, func() (*OrderBook, error) {
+		return c.Client.OrderBook(ctx, obr)
+	})
+}
+
+func (c *RetryingClient) AggTrades(ctx context.Context, atr AggTradesRequest) ([]*AggTrade, error) {
+	return retry(ctx, c.config, func() ([]*AggTrade, error) {
+		return c.Client.AggTrades(ctx, atr)
+	})
+}
+
+func (c *RetryingClient) RecentTrades(ctx context.Context, rtr RecentTradesRequest) ([]*MarketTrade, error) {
+	return retry(ctx, c.config, func() ([]*MarketTrade, error) {
+		return c.Client.RecentTrades(ctx, rtr)
+	})
+}
+
+func (c *RetryingClient) HistoricalTrades(ctx context.Context, htr HistoricalTradesRequest) ([]*MarketTrade, error) {
+	return retry(ctx, c.config, func() ([]*MarketTrade, error) {
+		return c.Client.HistoricalTrades(ctx, htr)
+	})
+}
+
+func (c *RetryingClient) Klines(ctx context.Context, kr KlinesRequest) ([]*Kline, error) {
+	return retry(ctx, c.config, func() ([]*Kline, error) {
+		return c.Client.Klines(ctx, kr)
+	})
+}
+
+func (c *RetryingClient) Ticker24(ctx context.Context, tr TickerRequest) ([]*SymbolTicker24, error) {
+	return retry(ctx, c.config, func() ([]*SymbolTicker24, error) {
+		return c.Client.Ticker24(ctx, tr)
+	})
+}
+
+func (c *RetryingClient) RollingWindowTicker(ctx context.Context, rwtr RollingWindowTickerRequest) ([]*SymbolTicker24, error) {
+	return retry(ctx, c.config, func() ([]*SymbolTicker24, error) {
+		return c.Client.RollingWindowTicker(ctx, rwtr)
+	})
+}
+
+func (c *RetryingClient) TickerAllPrices(ctx context.Context, tr TickerRequest) ([]*PriceTicker, error) {
+	return retry(ctx, c.config, func() ([]*PriceTicker, error) {
+		return c.Client.TickerAllPrices(ctx, tr)
+	})
+}
+
+func (c *RetryingClient) TickerAllBooks(ctx context.Context, tr TickerRequest) ([]*BookTicker, error) {
+	return retry(ctx, c.config, func() ([]*BookTicker, error) {
+		return c.Client.TickerAllBooks(ctx, tr)
+	})
+}
+
+// NewOrder places order, retrying only when nor.NewClientOrderID allows to
+// find out whether failed attempt was executed. Order found that way is
+// returned without Fills, which only FULL response carries.
+func (c *RetryingClient) NewOrder(ctx context.Context, nor NewOrderRequest) (*ProcessedOrder, error) {
+	if nor.NewClientOrderID == "" {
+		return c.Client.NewOrder(ctx, nor)
+	}
+	attempt := 0
+	return retry(ctx, c.config, func() (*ProcessedOrder, error) {
+		attempt++
+		if attempt > 1 {
+			eo, err := c.Client.QueryOrder(ctx, QueryOrderRequest{
+				Symbol:            nor.Symbol,
+				OrigClientOrderID: nor.NewClientOrderID,
+				RecvWindow:        nor.RecvWindow,
+			})
+			if err == nil {
+				po := &ProcessedOrder{
+					Symbol:              eo.Symbol,
+					OrderID:             int64(eo.OrderID),
+					ClientOrderID:       eo.ClientOrderID,
+					TransactTime:        eo.Time,
+					Price:               eo.Price,
+					OrigQty:             eo.OrigQty,
+					ExecutedQty:         eo.ExecutedQty,
+					CummulativeQuoteQty: eo.CummulativeQuoteQty,
+					Status:              eo.Status,
+					TimeInForce:         eo.TimeInForce,
+					Type:                eo.Type,
+					Side:                eo.Side,
+				}
+				return po, nil
+			}
+			if !errors.Is(err, ErrUnknownOrder) {
+				return nil, err
+			}
+		}
+		return c.Client.NewOrder(ctx, nor)
+	})
+}
+
+func (c *RetryingClient) NewOrderTest(ctx context.Context, nor NewOrderRequest) error {
+	_, err := retry(ctx, c.config, func() (struct{}, error) {
+		return struct{}{}, c.Client.NewOrderTest(ctx, nor)
+	})
+	return err
+}
+
+func (c *RetryingClient) QueryOrder(ctx context.Context, qor QueryOrderRequest) (*ExecutedOrder, error) {
+	return retry(ctx, c.config, func() (*ExecutedOrder, error) {
+		return c.Client.QueryOrder(ctx, qor)
+	})
+}
+
+func (c *RetryingClient) OpenOrders(ctx context.Context, oor OpenOrdersRequest) ([]*ExecutedOrder, error) {
+	return retry(ctx, c.config, func() ([]*ExecutedOrder, error) {
+		return c.Client.OpenOrders(ctx, oor)
+	})
+}
+
+func (c *RetryingClient) AllOrders(ctx context.Context, aor AllOrdersRequest) ([]*ExecutedOrder, error) {
+	return retry(ctx, c.config, func() ([]*ExecutedOrder, error) {
+		return c.Client.AllOrders(ctx, aor)
+	})
+}
+
+func (c *RetryingClient) Account(ctx context.Context, ar AccountRequest) (*Account, error) {
+	return retry(ctx, c.config, func() (*Account, error) {
+		return c.Client.Account(ctx, ar)
+	})
+}
+
+func (c *RetryingClient) MyTrades(ctx context.Context, mtr MyTradesRequest) ([]*Trade, error) {
+	return retry(ctx, c.config, func() ([]*Trade, error) {
+		return c.Client.MyTrades(ctx, mtr)
+	})
+}
+
+func (c *RetryingClient) DepositHistory(ctx context.Context, hr HistoryRequest) ([]*Deposit, error) {
+	return retry(ctx, c.config, func() ([]*Deposit, error) {
+		return c.Client.DepositHistory(ctx, hr)
+	})
+}
+
+func (c *RetryingClient) WithdrawHistory(ctx context.Context, hr HistoryRequest) ([]*Withdrawal, error) {
+	return retry(ctx, c.config, func() ([]*Withdrawal, error) {
+		return c.Client.WithdrawHistory(ctx, hr)
+	})
+}
+
+func (c *RetryingClient) KeepAliveUserDataStream(ctx context.Context, s *Stream) error {
+	_, err := retry(ctx, c.config, func() (struct{}, error) {
+		return struct{}{}, c.Client.KeepAliveUserDataStream(ctx, s)
+	})
+	return err
+}
+
+// retry calls call until it succeeds, fails with non-retryable error, runs
+// out of attempts or ctx is done.
+func retry[T any](ctx context.Context, config RetryConfig, call func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := call()
+		if err == nil || attempt >= config.MaxAttempts || !config.Retryable(err) {
+			return result, err
+		}
+		timer := time.NewTimer(config.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns delay after given failed attempt.
+func (config RetryConfig) backoff(attempt int, err error) time.Duration {
+	if delay := retryAfter(err); delay > 0 {
+		return delay
+	}
+	delay := float64(config.InitialBackoff) * math.Pow(config.Multiplier, float64(attempt-1))
+	if delay > float64(config.MaxBackoff) {
+		delay = float64(config.MaxBackoff)
+	}
+	if config.Jitter > 0 {
+		delay -= delay * config.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// retryAfter returns delay requested by the exchange or limiter, zero if
+// there's none.
+func retryAfter(err error) time.Duration {
+	var apiErr Error
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	var delayed interface{ RetryDelay() time.Duration }
+	if errors.As(err, &delayed) {
+		return delayed.RetryDelay()
+	}
+	return 0
+}
@@ -0,0 +1,247 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testRetryConfig retries without noticeable delay.
+var testRetryConfig = RetryConfig{
+	MaxAttempts:    3,
+	InitialBackoff: time.Microsecond,
+	Jitter:         NoJitter,
+}
+
+func newTestRetryingClient(t *testing.T, client Client, config RetryConfig) *RetryingClient {
+	t.Helper()
+	c, err := NewRetryingClient(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNewRetryingClientInvalidConfig(t *testing.T) {
+	for _, config := range []RetryConfig{
+		{MaxAttempts: -1},
+		{InitialBackoff: -time.Second},
+		{MaxBackoff: -time.Second},
+		{Multiplier: 0.5},
+		{Jitter: 1.5},
+		{Jitter: -0.5},
+	} {
+		if c, err := NewRetryingClient(&fakeClient{}, config); err == nil {
+			t.Errorf("NewRetryingClient(%+v) = %v, want error", config, c)
+		}
+	}
+}
+
+func TestRetryingClientRetryable(t *testing.T) {
+	tests := []struct {
+		err   error
+		calls int
+	}{
+		{Error{Code: CodeServerBusy, HTTPStatus: http.StatusServiceUnavailable}, 3},
+		{Error{Code: CodeTimeout}, 3},
+		{Error{HTTPStatus: http.StatusTooManyRequests}, 3},
+		{Error{HTTPStatus: http.StatusBadGateway}, 3},
+		{fmt.Errorf("read: %w", io.ErrUnexpectedEOF), 3},
+		{Error{Code: CodeInvalidSignature, HTTPStatus: http.StatusBadRequest}, 1},
+		{Error{HTTPStatus: http.StatusTeapot}, 1},
+		{context.Canceled, 1},
+		{errors.New("other"), 1},
+	}
+	for _, tt := range tests {
+		calls := 0
+		c := newTestRetryingClient(t, &fakeClient{ping: func(context.Context) error {
+			calls++
+			return tt.err
+		}}, testRetryConfig)
+		if err := c.Ping(context.Background()); !errors.Is(err, tt.err) {
+			t.Errorf("%v: Ping returned %v", tt.err, err)
+		}
+		if calls != tt.calls {
+			t.Errorf("%v: %d calls, want %d", tt.err, calls, tt.calls)
+		}
+	}
+}
+
+func TestRetryingClientSucceedsAfterRetry(t *testing.T) {
+	calls := 0
+	c := newTestRetryingClient(t, &fakeClient{ping: func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return Error{Code: CodeDisconnected}
+		}
+		return nil
+	}}, testRetryConfig)
+	if err := c.Ping(context.Background()); err != nil || calls != 3 {
+		t.Errorf("Ping = %v after %d calls", err, calls)
+	}
+}
+
+type delayedError time.Duration
+
+func (e delayedError) Error() string             { return "delayed" }
+func (e delayedError) Retryable() bool           { return true }
+func (e delayedError) RetryDelay() time.Duration { return time.Duration(e) }
+
+func TestRetryAfterPrecedence(t *testing.T) {
+	config := RetryConfig{InitialBackoff: time.Second, Multiplier: 2, Jitter: NoJitter}.withDefaults()
+	tests := []struct {
+		err  error
+		want time.Duration
+	}{
+		{Error{HTTPStatus: http.StatusTooManyRequests, RetryAfter: 30 * time.Second}, 30 * time.Second},
+		{fmt.Errorf("wrapped: %w", Error{Code: CodeTooManyRequests, RetryAfter: time.Millisecond}), time.Millisecond},
+		{delayedError(5 * time.Second), 5 * time.Second},
+		{Error{HTTPStatus: http.StatusTooManyRequests}, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := config.backoff(2, tt.err); got != tt.want {
+			t.Errorf("backoff after %v = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+
+	// Retry-After shorter than backoff is used by retries too.
+	config = RetryConfig{MaxAttempts: 2, InitialBackoff: time.Hour, Jitter: NoJitter}
+	calls := 0
+	c := newTestRetryingClient(t, &fakeClient{ping: func(context.Context) error {
+		calls++
+		return Error{HTTPStatus: http.StatusTooManyRequests, RetryAfter: time.Millisecond}
+	}}, config)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.Ping(ctx); !errors.Is(err, ErrTooManyRequests) || calls != 2 {
+		t.Errorf("Ping = %v after %d calls", err, calls)
+	}
+}
+
+func TestRetryConfigBackoff(t *testing.T) {
+	config := RetryConfig{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         NoJitter,
+	}.withDefaults()
+	for attempt, want := range []time.Duration{100, 200, 300, 300} {
+		if got := config.backoff(attempt+1, nil); got != want*time.Millisecond {
+			t.Errorf("backoff(%d) = %s, want %s", attempt+1, got, want*time.Millisecond)
+		}
+	}
+
+	constant := RetryConfig{InitialBackoff: time.Second, Multiplier: 1, Jitter: NoJitter}.withDefaults()
+	for attempt := 1; attempt <= 5; attempt++ {
+		if got := constant.backoff(attempt, nil); got != time.Second {
+			t.Errorf("constant backoff(%d) = %s", attempt, got)
+		}
+	}
+
+	// Zero Jitter means default one, subtracting up to half of delay.
+	jittered := RetryConfig{InitialBackoff: time.Second, Multiplier: 1}.withDefaults()
+	for i := 0; i < 100; i++ {
+		if got := jittered.backoff(1, nil); got < time.Second/2 || got > time.Second {
+			t.Fatalf("jittered backoff = %s", got)
+		}
+	}
+}
+
+func TestRetryingClientNewOrder(t *testing.T) {
+	nor := NewOrderRequest{
+		Symbol:           "BTCUSDT",
+		Side:             SideBuy,
+		Type:             TypeLimit,
+		TimeInForce:      GTC,
+		Price:            MustParseDecimal("20"),
+		Quantity:         MustParseDecimal("1"),
+		NewClientOrderID: "my-order",
+	}
+	timeout := Error{Code: CodeTimeout}
+	unknown := Error{Code: CodeNoSuchOrder, Message: "Order does not exist."}
+	placed := &ProcessedOrder{Symbol: "BTCUSDT", OrderID: 2, ClientOrderID: "my-order"}
+	found := &ExecutedOrder{
+		Symbol:        "BTCUSDT",
+		OrderID:       1,
+		ClientOrderID: "my-order",
+		Price:         MustParseDecimal("20"),
+		OrigQty:       MustParseDecimal("1"),
+		ExecutedQty:   MustParseDecimal("0.5"),
+		Status:        StatusPartiallyFilled,
+		TimeInForce:   GTC,
+		Type:          TypeLimit,
+		Side:          SideBuy,
+	}
+
+	tests := []struct {
+		name string
+		// clientOrderID replaces one of nor when set.
+		clientOrderID *string
+		newOrder      []error
+		queryOrder    []error
+		wantOrderID   int64
+		wantErr       error
+		wantNewOrders int
+		wantQueries   int
+	}{
+		{name: "found after failure", newOrder: []error{timeout}, queryOrder: []error{nil}, wantOrderID: 1, wantNewOrders: 1, wantQueries: 1},
+		{name: "placed again", newOrder: []error{timeout, nil}, queryOrder: []error{unknown}, wantOrderID: 2, wantNewOrders: 2, wantQueries: 1},
+		{name: "query failure", newOrder: []error{timeout}, queryOrder: []error{timeout, nil}, wantOrderID: 1, wantNewOrders: 1, wantQueries: 2},
+		{name: "not retryable", newOrder: []error{unknown}, wantErr: unknown, wantNewOrders: 1},
+		{name: "no client order ID", clientOrderID: new(string), newOrder: []error{timeout}, wantErr: timeout, wantNewOrders: 1},
+	}
+	for _, tt := range tests {
+		var newOrders, queries int
+		client := &fakeClient{
+			newOrder: func(context.Context, NewOrderRequest) (*ProcessedOrder, error) {
+				err := tt.newOrder[newOrders]
+				newOrders++
+				if err != nil {
+					return nil, err
+				}
+				return placed, nil
+			},
+			queryOrder: func(_ context.Context, qor QueryOrderRequest) (*ExecutedOrder, error) {
+				if qor.Symbol != nor.Symbol || qor.OrigClientOrderID != nor.NewClientOrderID || qor.OrderID != 0 {
+					t.Errorf("%s: unexpected query %+v", tt.name, qor)
+				}
+				err := tt.queryOrder[queries]
+				queries++
+				if err != nil {
+					return nil, err
+				}
+				return found, nil
+			},
+		}
+		c := newTestRetryingClient(t, client, testRetryConfig)
+		req := nor
+		if tt.clientOrderID != nil {
+			req.NewClientOrderID = *tt.clientOrderID
+		}
+		po, err := c.NewOrder(context.Background(), req)
+		if newOrders != tt.wantNewOrders || queries != tt.wantQueries {
+			t.Errorf("%s: %d orders placed, %d queried, want %d, %d", tt.name, newOrders, queries, tt.wantNewOrders, tt.wantQueries)
+		}
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: error %v, want %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if po.OrderID != tt.wantOrderID {
+			t.Errorf("%s: order %d, want %d", tt.name, po.OrderID, tt.wantOrderID)
+		}
+		if tt.wantOrderID == int64(found.OrderID) &&
+			(po.Status != found.Status || !po.ExecutedQty.Equal(found.ExecutedQty) || po.Side != found.Side || po.Type != found.Type) {
+			t.Errorf("%s: order state %+v doesn't match queried %+v", tt.name, po, found)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package binance
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// WebsocketNoticeType represents kind of WebsocketNotice.
+type WebsocketNoticeType string
+
+var (
+	// WebsocketDisconnected is sent when stream is closed by server or
+	// network failure.
+	WebsocketDisconnected = WebsocketNoticeType("DISCONNECTED")
+	// WebsocketReconnectFailed is sent for every failed reconnect attempt.
+	WebsocketReconnectFailed = WebsocketNoticeType("RECONNECT_FAILED")
+	// WebsocketReconnected is sent once stream is open again. Events
+	// between DisconnectedAt and Time are missing unless backfilled.
+	WebsocketReconnected = WebsocketNoticeType("RECONNECTED")
+	// WebsocketBackfillFailed is sent when missed klines couldn't be
+	// fetched after reconnect.
+	WebsocketBackfillFailed = WebsocketNoticeType("BACKFILL_FAILED")
+)
+
+// WebsocketNotice reports connection state change of supervised stream.
+type WebsocketNotice struct {
+	Type WebsocketNoticeType
+	// Stream is name of the stream like "btcusdt@kline_1m".
+	Stream         string
+	Time           time.Time
+	DisconnectedAt time.Time
+	Attempt        int
+	Err            error
+}
+
+// SupervisorConfig configures SupervisingClient.
+type SupervisorConfig struct {
+	// Backoff defines delays between reconnect attempts. MaxAttempts and
+	// Retryable are ignored, reconnecting goes on until context is done.
+	Backoff RetryConfig
+	// BackfillKlines makes KlineWebsocket fetch klines missed while
+	// disconnected with Klines and emit them before new events.
+	BackfillKlines bool
+	// OnNotice is called with every connection state change. It's called
+	// from the goroutine forwarding events, so it must not block.
+	OnNotice func(*WebsocketNotice)
+}
+
+// SupervisingClient is Client whose websocket streams survive
+// disconnections, including those forced by the exchange every 24 hours:
+// stream is reopened with backoff while returned channels stay the same
+// until context is done.
+type SupervisingClient struct {
+	Client
+	config SupervisorConfig
+}
+
+// NewSupervisingClient wraps client with websocket supervision. It returns
+// error if config.Backoff is invalid.
+func NewSupervisingClient(client Client, config SupervisorConfig) (*SupervisingClient, error) {
+	if err := config.Backoff.Validate(); err != nil {
+		return nil, err
+	}
+	config.Backoff = config.Backoff.withDefaults()
+	return &SupervisingClient{
+		Client: client,
+		config: config,
+	}, nil
+}
+
+func (c *SupervisingClient) DepthWebsocket(ctx context.Context, dwr DepthWebsocketRequest) (chan *DepthEvent, chan struct{}, error) {
+	return supervise(ctx, c.config, strings.ToLower(dwr.Symbol)+"@depth",
+		func(ctx context.Context) (chan *DepthEvent, chan struct{}, error) {
+			return c.Client.DepthWebsocket(ctx, dwr)
+		}, nil)
+}
+
+// KlineWebsocket serves kline events. When BackfillKlines is set, klines
+// since the one open at disconnection are fetched after reconnect and
+// emitted as events, closed klines with Final set. Events of reopened
+// stream received meanwhile are queued and emitted after them.
+func (c *SupervisingClient) KlineWebsocket(ctx context.Context, kwr KlineWebsocketRequest) (chan *KlineEvent, chan struct{}, error) {
+	stream := strings.ToLower(kwr.Symbol) + "@kline_" + string(kwr.Interval)
+	var resume func(context.Context, chan *KlineEvent, time.Time)
+	if c.config.BackfillKlines {
+		resume = func(ctx context.Context, events chan *KlineEvent, disconnectedAt time.Time) {
+			if err := c.backfillKlines(ctx, kwr, events, disconnectedAt); err != nil && ctx.Err() == nil {
+				c.config.notify(&WebsocketNotice{
+					Type:           WebsocketBackfillFailed,
+					Stream:         stream,
+					Time:           time.Now(),
+					DisconnectedAt: disconnectedAt,
+					Err:            err,
+				})
+			}
+		}
+	}
+	return supervise(ctx, c.config, stream,
+		func(ctx context.Context) (chan *KlineEvent, chan struct{}, error) {
+			return c.Client.KlineWebsocket(ctx, kwr)
+		}, resume)
+}
+
+func (c *SupervisingClient) TradeWebsocket(ctx context.Context, twr TradeWebsocketRequest) (chan *AggTradeEvent, chan struct{}, error) {
+	return supervise(ctx, c.config, strings.ToLower(twr.Symbol)+"@aggTrade",
+		func(ctx context.Context) (chan *AggTradeEvent, chan struct{}, error) {
+			return c.Client.TradeWebsocket(ctx, twr)
+		}, nil)
+}
+
+func (c *SupervisingClient) RawTradeWebsocket(ctx context.Context, twr TradeWebsocketRequest) (chan *MarketTradeEvent, chan struct{}, error) {
+	return supervise(ctx, c.config, strings.ToLower(twr.Symbol)+"@trade",
+		func(ctx context.Context) (chan *MarketTradeEvent, chan struct{}, error) {
+			return c.Client.RawTradeWebsocket(ctx, twr)
+		}, nil)
+}
+
+func (c *SupervisingClient) TickerWebsocket(ctx context.Context, twr TickerWebsocketRequest) (chan *Ticker24Event, chan struct{}, error) {
+	return supervise(ctx, c.config, tickerStream(twr, "ticker"),
+		func(ctx context.Context) (chan *Ticker24Event, chan struct{}, error) {
+			return c.Client.TickerWebsocket(ctx, twr)
+		}, nil)
+}
+
+func (c *SupervisingClient) MiniTickerWebsocket(ctx context.Context, twr TickerWebsocketRequest) (chan *MiniTickerEvent, chan struct{}, error) {
+	return supervise(ctx, c.config, tickerStream(twr, "miniTicker"),
+		func(ctx context.Context) (chan *MiniTickerEvent, chan struct{}, error) {
+			return c.Client.MiniTickerWebsocket(ctx, twr)
+		}, nil)
+}
+
+func (c *SupervisingClient) BookTickerWebsocket(ctx context.Context, twr TickerWebsocketRequest) (chan *BookTickerEvent, chan struct{}, error) {
+	if twr.Symbol == "" {
+		return nil, nil, ErrNoSymbol
+	}
+	return supervise(ctx, c.config, tickerStream(twr, "bookTicker"),
+		func(ctx context.Context) (chan *BookTickerEvent, chan struct{}, error) {
+			return c.Client.BookTickerWebsocket(ctx, twr)
+		}, nil)
+}
+
+func (c *SupervisingClient) UserDataWebsocket(ctx context.Context, udwr UserDataWebsocketRequest) (chan *UserDataEvent, chan struct{}, error) {
+	return supervise(ctx, c.config, "userData",
+		func(ctx context.Context) (chan *UserDataEvent, chan struct{}, error) {
+			return c.Client.UserDataWebsocket(ctx, udwr)
+		}, nil)
+}
+
+func (c *SupervisingClient) backfillKlines(ctx context.Context, kwr KlineWebsocketRequest, events chan *KlineEvent, disconnectedAt time.Time) error {
+	it := NewKlineIterator(c.Client, KlineRangeRequest{
+		Symbol:    kwr.Symbol,
+		Interval:  kwr.Interval,
+		StartTime: kwr.Interval.Truncate(disconnectedAt),
+	})
+	for it.Next(ctx) {
+		now := time.Now()
+		event := &KlineEvent{
+			WSEvent: WSEvent{
+				Type:   "kline",
+				Time:   now,
+				Symbol: kwr.Symbol,
+			},
+			Interval: kwr.Interval,
+			Final:    it.Kline().CloseTime.Before(now),
+			Kline:    *it.Kline(),
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return it.Err()
+}
+
+// tickerStream returns stream name of symbol ticker, or of all symbols
+// ticker like "!miniTicker@arr".
+func tickerStream(twr TickerWebsocketRequest, name string) string {
+	if twr.Symbol == "" {
+		return "!" + name + "@arr"
+	}
+	return strings.ToLower(twr.Symbol) + "@" + name
+}
+
+func (config SupervisorConfig) notify(notice *WebsocketNotice) {
+	if config.OnNotice != nil {
+		config.OnNotice(notice)
+	}
+}
+
+// supervise forwards events of stream opened by open to returned channel,
+// reopening stream whenever it's closed before ctx is done. resume, if set,
+// is called after reconnect to emit events missed while disconnected, see
+// resumeQueueing.
+func supervise[T any](
+	ctx context.Context,
+	config SupervisorConfig,
+	stream string,
+	open func(context.Context) (chan T, chan struct{}, error),
+	resume func(context.Context, chan T, time.Time),
+) (chan T, chan struct{}, error) {
+	events, _, err := open(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan T)
+	doneC := make(chan struct{})
+	go func() {
+		defer close(doneC)
+		defer close(out)
+		for {
+			for event := range events {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			disconnectedAt := time.Now()
+			config.notify(&WebsocketNotice{
+				Type:           WebsocketDisconnected,
+				Stream:         stream,
+				Time:           disconnectedAt,
+				DisconnectedAt: disconnectedAt,
+			})
+			events = reconnect(ctx, config, stream, open, disconnectedAt)
+			if events == nil {
+				return
+			}
+			if resume != nil {
+				resumeQueueing(ctx, out, events, resume, disconnectedAt)
+			}
+		}
+	}()
+	return out, doneC, nil
+}
+
+// resumeQueueing runs resume while draining events of reopened stream into
+// queue, so that slow resume neither stalls the stream nor makes its buffer
+// drop events, then forwards queued events after resumed ones.
+func resumeQueueing[T any](
+	ctx context.Context,
+	out chan T,
+	events chan T,
+	resume func(context.Context, chan T, time.Time),
+	disconnectedAt time.Time,
+) {
+	resumed := make(chan struct{})
+	go func() {
+		defer close(resumed)
+		resume(ctx, out, disconnectedAt)
+	}()
+	var queue []T
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Stream closed again, it's handled once queue is
+				// forwarded.
+				events = nil
+				continue
+			}
+			queue = append(queue, event)
+		case <-resumed:
+			for _, event := range queue {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			return
+		}
+	}
+}
+
+// reconnect opens stream with backoff, returning nil once ctx is done.
+func reconnect[T any](
+	ctx context.Context,
+	config SupervisorConfig,
+	stream string,
+	open func(context.Context) (chan T, chan struct{}, error),
+	disconnectedAt time.Time,
+) chan T {
+	var err error
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(config.Backoff.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		var events chan T
+		events, _, err = open(ctx)
+		notice := &WebsocketNotice{
+			Type:           WebsocketReconnected,
+			Stream:         stream,
+			Time:           time.Now(),
+			DisconnectedAt: disconnectedAt,
+			Attempt:        attempt,
+			Err:            err,
+		}
+		if err != nil {
+			notice.Type = WebsocketReconnectFailed
+		}
+		config.notify(notice)
+		if err == nil {
+			return events
+		}
+	}
+}
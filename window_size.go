@@ -0,0 +1,31 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidWindowSize means window size isn't supported by exchange.
+	ErrInvalidWindowSize = errors.New("invalid window size")
+	// ErrNoSymbol means request requiring symbol, like rolling window
+	// ticker or book ticker stream, has none.
+	ErrNoSymbol = errors.New("no symbol requested")
+)
+
+// FormatWindowSize returns rolling window ticker window size like "4h".
+// Supported sizes are whole 1-59 minutes, 1-23 hours or 1-7 days.
+func FormatWindowSize(d time.Duration) (string, error) {
+	const day = 24 * time.Hour
+	switch {
+	case d%day == 0 && d >= day && d <= 7*day:
+		return strconv.FormatInt(int64(d/day), 10) + "d", nil
+	case d%time.Hour == 0 && d >= time.Hour && d < day:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h", nil
+	case d%time.Minute == 0 && d >= time.Minute && d < time.Hour:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m", nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidWindowSize, d)
+}